@@ -0,0 +1,247 @@
+package yellowcard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without making a request while the circuit breaker is open.
+var ErrCircuitOpen = errors.New("yellowcard: circuit breaker is open")
+
+// CircuitState is the state of a circuit breaker.
+type CircuitState uint8
+
+const (
+	// CircuitClosed lets all requests through while counting failures.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen fails all requests with ErrCircuitOpen.
+	CircuitOpen
+	// CircuitHalfOpen lets a limited number of probe requests through to decide whether to close the circuit.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreakerConfig configures when the circuit breaker opens and how it recovers.
+// Connection errors and responses with status 429 or 5xx count as failures.
+type CircuitBreakerConfig struct {
+	// ConsecutiveFailures opens the circuit after this many failures in a row. Zero disables the threshold.
+	ConsecutiveFailures int
+	// FailureRatio opens the circuit once the ratio of failed requests within Interval reaches it.
+	// Zero disables the threshold.
+	FailureRatio float64
+	// MinRequests is the number of requests within Interval required before FailureRatio is evaluated.
+	MinRequests int
+	// Interval is the window over which FailureRatio is measured. Defaults to 1 minute.
+	Interval time.Duration
+	// OpenTimeout is how long the circuit stays open before letting probe requests through. Defaults to 30 seconds.
+	OpenTimeout time.Duration
+	// HalfOpenRequests is the number of successful probe requests required to close the circuit. Defaults to 1.
+	HalfOpenRequests int
+	// OnStateChange is called whenever the circuit changes state.
+	OnStateChange func(from CircuitState, to CircuitState)
+}
+
+// WithCircuitBreaker configures the ClientConfig to stop sending requests while the API is failing.
+// Requests made while the circuit is open fail immediately with ErrCircuitOpen.
+func WithCircuitBreaker(cfg CircuitBreakerConfig) func(config *ClientConfig) {
+	return func(config *ClientConfig) {
+		config.breaker = newCircuitBreaker(cfg)
+	}
+}
+
+// CircuitState returns the state of the client's circuit breaker. It is always CircuitClosed when no circuit
+// breaker is configured.
+func (cl *Client) CircuitState() CircuitState {
+	return cl.config.breaker.state()
+}
+
+// circuitBreaker tracks request outcomes and rejects requests while the circuit is open.
+type circuitBreaker struct {
+	mu          sync.Mutex
+	cfg         CircuitBreakerConfig
+	now         func() time.Time
+	current     CircuitState
+	openedAt    time.Time
+	windowStart time.Time
+	requests    int
+	failures    int
+	consecutive int
+	probes      int
+	successes   int
+}
+
+func newCircuitBreaker(cfg CircuitBreakerConfig) *circuitBreaker {
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Minute
+	}
+
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = 30 * time.Second
+	}
+
+	cfg.HalfOpenRequests = max(cfg.HalfOpenRequests, 1)
+
+	return &circuitBreaker{cfg: cfg, now: time.Now}
+}
+
+// state returns the current state of the circuit.
+func (b *circuitBreaker) state() CircuitState {
+	if b == nil {
+		return CircuitClosed
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.current
+}
+
+// allow returns ErrCircuitOpen if a request may not be sent. Every allowed request must be followed by a call
+// to record.
+func (b *circuitBreaker) allow() error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+
+	var (
+		now        = b.now()
+		halfOpened bool
+		err        error
+	)
+
+	if b.current == CircuitOpen && now.Sub(b.openedAt) >= b.cfg.OpenTimeout {
+		b.setState(CircuitHalfOpen, now)
+		halfOpened = true
+	}
+
+	switch b.current {
+	case CircuitClosed:
+		if now.Sub(b.windowStart) >= b.cfg.Interval {
+			b.windowStart, b.requests, b.failures = now, 0, 0
+		}
+
+		b.requests++
+	case CircuitOpen:
+		err = ErrCircuitOpen
+	case CircuitHalfOpen:
+		if b.probes >= b.cfg.HalfOpenRequests {
+			err = ErrCircuitOpen
+			break
+		}
+
+		b.probes++
+	}
+
+	b.mu.Unlock()
+
+	if halfOpened {
+		b.notify(CircuitOpen, CircuitHalfOpen)
+	}
+
+	return err
+}
+
+// record updates the circuit with the outcome of an allowed request. Requests cancelled by their caller are not
+// counted, while requests whose deadline passed or that returned no response are counted as failures.
+func (b *circuitBreaker) record(ctx context.Context, resp *http.Response, err error) {
+	if b == nil {
+		return
+	}
+
+	var (
+		aborted = err != nil && errors.Is(ctx.Err(), context.Canceled)
+		failed  = err != nil || resp == nil || resp.StatusCode == http.StatusTooManyRequests ||
+			resp.StatusCode >= http.StatusInternalServerError
+	)
+
+	b.mu.Lock()
+
+	var (
+		now      = b.now()
+		from, to = b.current, b.current
+	)
+
+	switch b.current {
+	case CircuitClosed:
+		switch {
+		case aborted:
+			b.requests = max(b.requests-1, 0)
+		case failed:
+			b.failures++
+			b.consecutive++
+
+			if b.shouldOpen() {
+				to = CircuitOpen
+			}
+		default:
+			b.consecutive = 0
+		}
+	case CircuitHalfOpen:
+		switch {
+		case aborted:
+			b.probes = max(b.probes-1, 0)
+		case failed:
+			to = CircuitOpen
+		default:
+			b.successes++
+			if b.successes >= b.cfg.HalfOpenRequests {
+				to = CircuitClosed
+			}
+		}
+	}
+
+	if to != from {
+		b.setState(to, now)
+	}
+
+	b.mu.Unlock()
+
+	if to != from {
+		b.notify(from, to)
+	}
+}
+
+// shouldOpen reports whether the failures counted while closed exceed a threshold.
+func (b *circuitBreaker) shouldOpen() bool {
+	if b.cfg.ConsecutiveFailures > 0 && b.consecutive >= b.cfg.ConsecutiveFailures {
+		return true
+	}
+
+	return b.cfg.FailureRatio > 0 && b.requests >= b.cfg.MinRequests &&
+		float64(b.failures)/float64(b.requests) >= b.cfg.FailureRatio
+}
+
+// setState moves the circuit to the given state and resets its counters.
+func (b *circuitBreaker) setState(state CircuitState, now time.Time) {
+	b.current = state
+	b.windowStart, b.requests, b.failures, b.consecutive = now, 0, 0, 0
+	b.probes, b.successes = 0, 0
+
+	if state == CircuitOpen {
+		b.openedAt = now
+	}
+}
+
+// notify calls the OnStateChange callback. It must be called without holding the lock.
+func (b *circuitBreaker) notify(from CircuitState, to CircuitState) {
+	if b.cfg.OnStateChange != nil {
+		b.cfg.OnStateChange(from, to)
+	}
+}
@@ -0,0 +1,165 @@
+package yellowcard
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_CircuitBreaker(t *testing.T) {
+	var (
+		httpClient = newMockHttpClient()
+		changes    []string
+		client     = newTestClient(t,
+			WithHttpClient(httpClient),
+			WithCircuitBreaker(CircuitBreakerConfig{
+				ConsecutiveFailures: 2,
+				OpenTimeout:         time.Minute,
+				OnStateChange: func(from CircuitState, to CircuitState) {
+					changes = append(changes, from.String()+"->"+to.String())
+				},
+			}),
+		)
+		now    = time.Date(2024, time.June, 14, 16, 20, 0, 0, time.UTC)
+		status = http.StatusServiceUnavailable
+		ctx    = context.Background()
+	)
+
+	client.config.breaker.now = func() time.Time { return now }
+
+	httpClient.MockRequest(client.config.baseURL+"/business/account", func() (int, string) {
+		return status, `{"code":"ServiceUnavailable","message":"try again"}`
+	})
+
+	// Test client errors are not counted as failures
+	status = http.StatusBadRequest
+	_, err := client.GetAccount(ctx)
+	assert.Error(t, err)
+
+	status = http.StatusServiceUnavailable
+	for range 2 {
+		_, err = client.GetAccount(ctx)
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrCircuitOpen)
+	}
+
+	assert.Equal(t, CircuitOpen, client.CircuitState())
+	assert.Equal(t, []string{"closed->open"}, changes)
+
+	// Test requests fail fast while the circuit is open
+	_, err = client.GetAccount(ctx)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Len(t, httpClient.requests, 3)
+
+	// Test a failed probe opens the circuit again
+	now = now.Add(time.Minute)
+	_, err = client.GetAccount(ctx)
+	assert.NotErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, CircuitOpen, client.CircuitState())
+	assert.Len(t, httpClient.requests, 4)
+
+	// Test a successful probe closes the circuit
+	now = now.Add(time.Minute)
+	status = http.StatusOK
+	_, err = client.GetAccount(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, CircuitClosed, client.CircuitState())
+	assert.Equal(t, []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}, changes)
+}
+
+// hangingHttpClient never responds, returning once the request context is done.
+type hangingHttpClient struct{}
+
+func (hangingHttpClient) Do(req *http.Request) (*http.Response, error) {
+	<-req.Context().Done()
+	return nil, req.Context().Err()
+}
+
+func TestClient_CircuitBreakerTimeouts(t *testing.T) {
+	client := newTestClient(t,
+		WithHttpClient(hangingHttpClient{}),
+		WithOperationTimeout(OperationGetAccount, 10*time.Millisecond),
+		WithCircuitBreaker(CircuitBreakerConfig{ConsecutiveFailures: 2, OpenTimeout: time.Minute}),
+	)
+
+	for range 2 {
+		_, err := client.GetAccount(context.Background())
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	}
+
+	assert.Equal(t, CircuitOpen, client.CircuitState())
+
+	_, err := client.GetAccount(context.Background())
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+}
+
+// nilHttpClient returns neither a response nor an error.
+type nilHttpClient struct{}
+
+func (nilHttpClient) Do(*http.Request) (*http.Response, error) {
+	return nil, nil
+}
+
+func TestClient_CircuitBreakerNilResponse(t *testing.T) {
+	client := newTestClient(t,
+		WithHttpClient(nilHttpClient{}),
+		WithCircuitBreaker(CircuitBreakerConfig{ConsecutiveFailures: 3, OpenTimeout: time.Minute}),
+	)
+
+	for range 3 {
+		_, err := client.GetRates(context.Background(), "")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "no response returned")
+	}
+
+	assert.Equal(t, CircuitOpen, client.CircuitState())
+}
+
+func TestCircuitBreaker_FailureRatio(t *testing.T) {
+	var (
+		breaker = newCircuitBreaker(CircuitBreakerConfig{FailureRatio: 0.5, MinRequests: 4, Interval: time.Minute})
+		now     = time.Date(2024, time.June, 14, 16, 20, 0, 0, time.UTC)
+		ctx     = context.Background()
+		ok      = mockHttpResponse(http.StatusOK, "")
+		failed  = mockHttpResponse(http.StatusBadGateway, "")
+	)
+
+	breaker.now = func() time.Time { return now }
+
+	for _, resp := range []*http.Response{failed, ok, failed} {
+		assert.NoError(t, breaker.allow())
+		breaker.record(ctx, resp, nil)
+	}
+
+	assert.Equal(t, CircuitClosed, breaker.state())
+
+	// Test requests cancelled by their caller are not counted
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	assert.NoError(t, breaker.allow())
+	breaker.record(cancelled, nil, context.Canceled)
+	assert.Equal(t, CircuitClosed, breaker.state())
+
+	// Test the request count does not go negative when the window resets before a cancelled request is recorded
+	assert.NoError(t, breaker.allow())
+	breaker.windowStart, breaker.requests, breaker.failures = now, 0, 0
+	breaker.record(cancelled, nil, context.Canceled)
+	assert.Zero(t, breaker.requests)
+
+	// Test counts are reset after the interval
+	now = now.Add(time.Minute)
+	for _, resp := range []*http.Response{failed, ok, ok} {
+		assert.NoError(t, breaker.allow())
+		breaker.record(ctx, resp, nil)
+	}
+
+	assert.Equal(t, CircuitClosed, breaker.state())
+
+	assert.NoError(t, breaker.allow())
+	breaker.record(ctx, failed, nil)
+	assert.Equal(t, CircuitOpen, breaker.state())
+	assert.ErrorIs(t, breaker.allow(), ErrCircuitOpen)
+}
@@ -3,13 +3,18 @@ package yellowcard
 import (
 	"bytes"
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"iter"
+	"log/slog"
+	"math"
 	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -18,8 +23,45 @@ const (
 	_sandboxBaseURL = "https://sandbox.api.yellowcard.io"
 )
 
+// DefaultPerPage is the number of records requested per page when none is specified.
+const DefaultPerPage = 50
+
 var _httpClient = &http.Client{}
 
+// _mobileMoneyNumberRegex matches phone numbers with an optional leading + followed by 7 to 15 digits.
+var _mobileMoneyNumberRegex = regexp.MustCompile(`^\+?[0-9]{7,15}$`)
+
+// ErrInvalidMobileMoneyNumber is returned when the provided mobile money number is not a valid phone number.
+var ErrInvalidMobileMoneyNumber = errors.New("yellowcard: invalid mobile money number")
+
+// ErrInvalidQuoteAmount is returned when a quote request does not set exactly one of Amount or LocalAmount.
+var ErrInvalidQuoteAmount = errors.New("yellowcard: exactly one of amount or local amount is required")
+
+// ErrChannelNotFound is returned when the requested channel does not exist or is not active.
+var ErrChannelNotFound = errors.New("yellowcard: channel not found")
+
+// ErrRateNotFound is returned when no rate is available for the requested currency.
+var ErrRateNotFound = errors.New("yellowcard: rate not found")
+
+// ErrAmountOutOfRange is returned when an amount is outside the limits of a channel.
+var ErrAmountOutOfRange = errors.New("yellowcard: amount is outside the channel limits")
+
+// ErrBusinessSenderRequired is returned when an institution payment does not include the business name and ID
+// of its sender.
+var ErrBusinessSenderRequired = errors.New("yellowcard: business sender name and ID are required")
+
+// ErrInvalidEnvironment is returned by New when WithEnvironment is given an unknown Environment.
+var ErrInvalidEnvironment = errors.New("yellowcard: invalid environment")
+
+// ErrInvalidBaseURL is returned by New when WithBaseURL is given a URL that is not an absolute http(s) URL.
+var ErrInvalidBaseURL = errors.New("yellowcard: invalid base URL")
+
+// ErrKeyRequired is returned by New when the API key is empty.
+var ErrKeyRequired = errors.New("yellowcard: API key is required")
+
+// ErrSecretRequired is returned by New when the API secret is empty.
+var ErrSecretRequired = errors.New("yellowcard: API secret is required")
+
 // HttpClient is an interface representing an HTTP client capable of making HTTP requests.
 type HttpClient interface {
 	Do(req *http.Request) (*http.Response, error)
@@ -37,16 +79,31 @@ const (
 
 // Client represents a client for interacting with the API.
 type Client struct {
-	config *ClientConfig
-	key    string
-	secret string
+	config  *ClientConfig
+	handler Handler
 }
 
 // ClientConfig is used to configure a new Client backend.
 type ClientConfig struct {
-	baseURL    string
-	env        Environment
-	httpClient HttpClient
+	baseURL            string
+	env                Environment
+	err                error
+	breaker            *circuitBreaker
+	clock              Clock
+	credentials        CredentialsProvider
+	customBaseURL      string
+	httpClient         HttpClient
+	limiter            *rateLimiter
+	middleware         []Middleware
+	logBodies          bool
+	logger             *slog.Logger
+	metrics            Metrics
+	operationTimeouts  map[Operation]time.Duration
+	retryPolicy        *RetryPolicy
+	safeSubmitAttempts int
+	skew               *skewEstimator
+	timeouts           Timeouts
+	tracer             Tracer
 }
 
 // DefaultConfig returns a default configuration for creating a ClientConfig instance.
@@ -54,22 +111,42 @@ type ClientConfig struct {
 func DefaultConfig() *ClientConfig {
 	return &ClientConfig{
 		baseURL:    _prodBaseURL,
+		clock:      ClockFunc(time.Now),
 		env:        EnvironmentProduction,
 		httpClient: _httpClient,
+		timeouts:   DefaultTimeouts(),
 	}
 }
 
 // WithEnvironment configures the ClientConfig based on the specified environment.
+// New returns ErrInvalidEnvironment if the environment is unknown.
 func WithEnvironment(env Environment) func(config *ClientConfig) {
 	return func(config *ClientConfig) {
 		switch env {
 		case EnvironmentSandbox:
 			config.env = env
 			config.baseURL = _sandboxBaseURL
-		default:
-			config.env = EnvironmentProduction
+		case EnvironmentProduction:
+			config.env = env
 			config.baseURL = _prodBaseURL
+		default:
+			config.err = errors.Join(config.err, fmt.Errorf("%w: %d", ErrInvalidEnvironment, env))
+		}
+	}
+}
+
+// WithBaseURL configures the ClientConfig to send requests to the base URL instead of the one of its
+// Environment, e.g. to use a proxy or a local stand-in for the API, whatever the order of the options.
+// New returns ErrInvalidBaseURL if the URL is not an absolute http or https URL.
+func WithBaseURL(baseURL string) func(config *ClientConfig) {
+	return func(config *ClientConfig) {
+		u, err := url.Parse(baseURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			config.err = errors.Join(config.err, fmt.Errorf("%w: %q", ErrInvalidBaseURL, baseURL))
+			return
 		}
+
+		config.customBaseURL = strings.TrimSuffix(u.String(), "/")
 	}
 }
 
@@ -82,60 +159,106 @@ func WithHttpClient(cl HttpClient) func(config *ClientConfig) {
 	}
 }
 
-// getHeaders generates HTTP headers required for authentication using HMAC with SHA-256.
-func (cl *Client) getHeaders(method string, path string, body []byte, timeUTC time.Time) map[string]string {
+// call makes the http request, retrying failed attempts according to the configured RetryPolicy. Unless ctx
+// has a deadline, all attempts are bounded by the configured timeout of the operation.
+func (cl *Client) call(
+	ctx context.Context,
+	op Operation,
+	method string,
+	path string,
+	body *bytes.Buffer,
+	params map[string]string,
+) ([]byte, error) {
 	var (
-		timeStr = timeUTC.Format(time.RFC3339)
-		mac     = hmac.New(sha256.New, []byte(cl.secret))
+		payload = body.Bytes()
+		policy  = cl.config.retryPolicy
 	)
 
-	mac.Write([]byte(timeStr))
-	mac.Write([]byte(path))
-	mac.Write([]byte(method))
-
-	headers := map[string]string{
-		"Accept": "application/json",
-	}
+	ctx, cancel := cl.config.withDefaultTimeout(ctx, op)
+	defer cancel()
+
+	for n := 1; ; n++ {
+		cl.requestStarted(op)
+		start := time.Now()
+
+		resp, resBody, err := cl.send(ctx, op, method, path, payload, params)
+
+		cl.observe(ctx, &attempt{
+			op:       op,
+			method:   method,
+			path:     path,
+			number:   n,
+			latency:  time.Since(start),
+			reqBody:  payload,
+			resBody:  resBody,
+			response: resp,
+			err:      err,
+		})
+
+		if err == nil {
+			return resBody, nil
+		}
 
-	if body != nil {
-		var (
-			bodyHash    = sha256.Sum256(body)
-			bodyHmacStr = base64.StdEncoding.EncodeToString(bodyHash[:])
-		)
+		if !policy.shouldRetry(ctx, op, n, resp, err) {
+			return nil, err
+		}
 
-		mac.Write([]byte(bodyHmacStr))
+		if waitErr := sleep(ctx, policy.backoff(n, resp)); waitErr != nil {
+			return nil, err
+		}
 
-		headers["Content-Type"] = "application/json charset=utf-8"
+		cl.requestRetried(op)
 	}
+}
 
-	var (
-		sum       = mac.Sum(nil)
-		signature = base64.StdEncoding.EncodeToString(sum)
-	)
+// attempt describes a single http request made by Client.call.
+type attempt struct {
+	op       Operation
+	method   string
+	path     string
+	number   int
+	latency  time.Duration
+	reqBody  []byte
+	resBody  []byte
+	response *http.Response
+	err      error
+}
 
-	headers["Authorization"] = fmt.Sprintf("YcHmacV1 %s:%s", cl.key, signature)
-	headers["X-YC-Timestamp"] = timeStr
-	return headers
+// observe reports a completed attempt to the configured logger and metrics, and the span of its operation.
+func (cl *Client) observe(ctx context.Context, a *attempt) {
+	cl.logAttempt(ctx, a)
+	cl.recordAttempt(a)
+	traceAttempt(ctx, a)
 }
 
-// call sets all the required headers and makes all http requests
-func (cl *Client) call(
+// send sets all the required headers and makes a single http request. The response and its body are returned
+// alongside any error whenever they were received.
+func (cl *Client) send(
 	ctx context.Context,
+	op Operation,
 	method string,
 	path string,
-	body *bytes.Buffer,
+	payload []byte,
 	params map[string]string,
-) ([]byte, error) {
+) (*http.Response, []byte, error) {
 	uri := cl.config.baseURL + path
 
-	req, err := http.NewRequestWithContext(ctx, method, uri, body)
+	req, err := http.NewRequestWithContext(ctx, method, uri, bytes.NewReader(payload))
 	if err != nil {
-		return nil, fmt.Errorf("yellowcard: create request - %v", err)
+		return nil, nil, fmt.Errorf("yellowcard: create request - %v", err)
+	}
+
+	// Wait for the rate limiter before signing so that the request is not sent with a stale X-YC-Timestamp.
+	if err = cl.config.limiter.wait(ctx, op); err != nil {
+		return nil, nil, err
 	}
 
-	now := time.Now().UTC()
+	creds, err := cl.credentials(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	headers := cl.getHeaders(method, path, body.Bytes(), now)
+	headers := signatureHeaders(creds.Key, creds.Secret, method, path, payload, cl.now())
 	for key, value := range headers {
 		req.Header.Set(key, value)
 	}
@@ -149,57 +272,111 @@ func (cl *Client) call(
 		req.URL.RawQuery = q.Encode()
 	}
 
-	resp, err := cl.config.httpClient.Do(req)
+	resp, err := cl.handler(op, req)
 	if err != nil {
-		return nil, fmt.Errorf("yellowcard: do request - %v", err)
+		return nil, nil, err
+	}
+
+	if resp == nil {
+		return nil, nil, fmt.Errorf("yellowcard: do request - no response returned for %s", op)
 	}
 
+	cl.config.skew.observe(resp, cl.config.clock.Now())
+
 	defer func(r io.ReadCloser) {
 		_ = r.Close()
 	}(resp.Body)
 
 	resBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("yellowcard: read response body - %v", err)
+		return resp, nil, fmt.Errorf("yellowcard: read response body - %w", err)
 	}
 
 	if resp.StatusCode >= http.StatusBadRequest {
 		errResp := &errorResponse{StatusCode: resp.StatusCode}
 
 		if err = json.Unmarshal(resBody, errResp); err != nil {
-			return nil, fmt.Errorf("yellowcard: deserialize error response - %v", err)
+			return resp, resBody, fmt.Errorf("yellowcard: deserialize error response - %v", err)
 		}
 
-		return nil, fmt.Errorf("%s", errResp)
+		return resp, resBody, errResp
 	}
 
-	return resBody, nil
+	return resp, resBody, nil
+}
+
+// do sends the signed request once it is allowed by the circuit breaker. It is the innermost Handler of the
+// middleware chain.
+func (cl *Client) do(op Operation, req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if err := cl.config.breaker.allow(); err != nil {
+		return nil, err
+	}
+
+	resp, err := cl.config.httpClient.Do(req)
+	cl.config.breaker.record(ctx, resp, err)
+
+	if err != nil {
+		return nil, fmt.Errorf("yellowcard: do request - %w", err)
+	}
+
+	return resp, nil
 }
 
 // doGetRequest handles all http.MethodGet requests
-func (cl *Client) doGetRequest(ctx context.Context, path string, params map[string]string) ([]byte, error) {
+func (cl *Client) doGetRequest(
+	ctx context.Context,
+	op Operation,
+	path string,
+	params map[string]string,
+) ([]byte, error) {
 	body := &bytes.Buffer{}
-	return cl.call(ctx, http.MethodGet, path, body, params)
+	return cl.call(ctx, op, http.MethodGet, path, body, params)
 }
 
 // doGetRequest handles all http.MethodPost requests
-func (cl *Client) doPostRequest(ctx context.Context, path string, body *bytes.Buffer) ([]byte, error) {
-	return cl.call(ctx, http.MethodPost, path, body, nil)
+func (cl *Client) doPostRequest(ctx context.Context, op Operation, path string, body *bytes.Buffer) ([]byte, error) {
+	return cl.call(ctx, op, http.MethodPost, path, body, nil)
+}
+
+// doPutRequest handles all http.MethodPut requests
+func (cl *Client) doPutRequest(ctx context.Context, op Operation, path string, body *bytes.Buffer) ([]byte, error) {
+	return cl.call(ctx, op, http.MethodPut, path, body, nil)
+}
+
+// doDeleteRequest handles all http.MethodDelete requests
+func (cl *Client) doDeleteRequest(ctx context.Context, op Operation, path string) ([]byte, error) {
+	body := &bytes.Buffer{}
+	return cl.call(ctx, op, http.MethodDelete, path, body, nil)
 }
 
 // GetChannels retrieves all supported payment ramps (Bank Transfer, Mobile Money, E-Wallets transfers)
 // By default only active channels are returned.
 func (cl *Client) GetChannels(ctx context.Context, country CountryCode) ([]*Channel, error) {
+	return cl.ListChannels(ctx, &ChannelOptions{Country: country})
+}
+
+// ListChannels retrieves the payment ramps matching the options.
+// Unless opts.IncludeInactive is set, only active channels are returned.
+func (cl *Client) ListChannels(ctx context.Context, opts *ChannelOptions) (_ []*Channel, err error) {
+	ctx, span := cl.startSpan(ctx, OperationListChannels, SpanAttributes{})
+	defer func() { span.end(nil, err) }()
+
+	if opts == nil {
+		opts = &ChannelOptions{}
+	}
+
 	params := make(map[string]string)
-	if country != "" {
-		if _, ok := CountryCodes[country]; !ok {
+	if opts.Country != "" {
+		if _, ok := CountryCodes[opts.Country]; !ok {
 			return nil, ErrCountryNotSupported
 		}
 
-		params["country"] = country.String()
+		params["country"] = opts.Country.String()
 	}
 
-	resBody, err := cl.doGetRequest(ctx, "/business/channels", params)
+	resBody, err := cl.doGetRequest(ctx, OperationListChannels, "/business/channels", params)
 	if err != nil {
 		return nil, err
 	}
@@ -209,29 +386,47 @@ func (cl *Client) GetChannels(ctx context.Context, country CountryCode) ([]*Chan
 		return nil, fmt.Errorf("yellowcard: deserialize channels response - %v", err)
 	}
 
-	var activeChannels []*Channel
+	var channels []*Channel
 	for _, channel := range resp.Channels {
-		if channel.Status == "active" {
-			activeChannels = append(activeChannels, channel)
+		switch {
+		case !opts.IncludeInactive && channel.Status != "active":
+		case opts.APIStatus != "" && channel.ApiStatus != opts.APIStatus:
+		case opts.ChannelType != "" && channel.ChannelType != string(opts.ChannelType):
+		case opts.RampType != "" && channel.RampType != string(opts.RampType):
+		default:
+			channels = append(channels, channel)
 		}
 	}
 
-	return activeChannels, nil
+	return channels, nil
 }
 
 // GetNetworks retrieves all supported end financial interfaces (Banks, Mobile Money Networks, E-Wallets)
 // By default only active networks are returned.
 func (cl *Client) GetNetworks(ctx context.Context, country CountryCode) ([]*Network, error) {
+	return cl.ListNetworks(ctx, &NetworkOptions{Country: country})
+}
+
+// ListNetworks retrieves the end financial interfaces matching the options.
+// Unless opts.IncludeInactive is set, only active networks are returned.
+func (cl *Client) ListNetworks(ctx context.Context, opts *NetworkOptions) (_ []*Network, err error) {
+	ctx, span := cl.startSpan(ctx, OperationListNetworks, SpanAttributes{})
+	defer func() { span.end(nil, err) }()
+
+	if opts == nil {
+		opts = &NetworkOptions{}
+	}
+
 	params := make(map[string]string)
-	if country != "" {
-		if _, ok := CountryCodes[country]; !ok {
+	if opts.Country != "" {
+		if _, ok := CountryCodes[opts.Country]; !ok {
 			return nil, ErrCountryNotSupported
 		}
 
-		params["country"] = country.String()
+		params["country"] = opts.Country.String()
 	}
 
-	resBody, err := cl.doGetRequest(ctx, "/business/networks", params)
+	resBody, err := cl.doGetRequest(ctx, OperationListNetworks, "/business/networks", params)
 	if err != nil {
 		return nil, err
 	}
@@ -243,7 +438,10 @@ func (cl *Client) GetNetworks(ctx context.Context, country CountryCode) ([]*Netw
 
 	var networks []*Network
 	for _, network := range resp.Networks {
-		if network.Status == "active" {
+		switch {
+		case !opts.IncludeInactive && network.Status != "active":
+		case opts.AccountNumberType != "" && network.AccountNumberType != string(opts.AccountNumberType):
+		default:
 			networks = append(networks, network)
 		}
 	}
@@ -252,7 +450,10 @@ func (cl *Client) GetNetworks(ctx context.Context, country CountryCode) ([]*Netw
 }
 
 // GetRates retrieves rates for supported countries.
-func (cl *Client) GetRates(ctx context.Context, currency CurrencyCode) ([]*Rate, error) {
+func (cl *Client) GetRates(ctx context.Context, currency CurrencyCode) (_ []*Rate, err error) {
+	ctx, span := cl.startSpan(ctx, OperationGetRates, SpanAttributes{})
+	defer func() { span.end(nil, err) }()
+
 	params := make(map[string]string)
 	if currency != "" {
 		if _, ok := CurrencyCodes[currency]; !ok {
@@ -262,7 +463,7 @@ func (cl *Client) GetRates(ctx context.Context, currency CurrencyCode) ([]*Rate,
 		params["currency"] = currency.String()
 	}
 
-	resBody, err := cl.doGetRequest(ctx, "/business/rates", params)
+	resBody, err := cl.doGetRequest(ctx, OperationGetRates, "/business/rates", params)
 	if err != nil {
 		return nil, err
 	}
@@ -275,11 +476,113 @@ func (cl *Client) GetRates(ctx context.Context, currency CurrencyCode) ([]*Rate,
 	return resp.Rates, nil
 }
 
+// Quote calculates the cost of sending or collecting an amount through a channel using the current rates and
+// the channel's fee. Withdraw channels are priced using the buy rate while deposit channels use the sell rate.
+// The fee is charged once: Channel.FeeLocal is used when set, otherwise Channel.FeeUSD converted with the rate.
+func (cl *Client) Quote(ctx context.Context, req *QuoteRequest) (_ *Quote, err error) {
+	ctx, span := cl.startSpan(ctx, OperationQuote, SpanAttributes{})
+	defer func() { span.end(nil, err) }()
+
+	if (req.Amount > 0) == (req.LocalAmount > 0) {
+		return nil, ErrInvalidQuoteAmount
+	}
+
+	channels, err := cl.ListChannels(ctx, &ChannelOptions{Country: req.Country})
+	if err != nil {
+		return nil, err
+	}
+
+	var channel *Channel
+	for _, c := range channels {
+		if c.ID == req.ChannelID {
+			channel = c
+			break
+		}
+	}
+
+	if channel == nil {
+		return nil, ErrChannelNotFound
+	}
+
+	currency := req.Currency
+	if currency == "" {
+		currency = CountryCodes[req.Country].CurrencyCode
+	}
+
+	if currency == "" {
+		currency = CurrencyCode(channel.Currency)
+	}
+
+	rates, err := cl.GetRates(ctx, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	var rate *Rate
+	for _, r := range rates {
+		if r.Code == currency.String() {
+			rate = r
+			break
+		}
+	}
+
+	quote := &Quote{
+		ChannelID: channel.ID,
+		Currency:  currency,
+		RampType:  RampType(channel.RampType),
+	}
+
+	if rate != nil {
+		quote.RateID = rate.RateID
+		quote.Rate = rate.Buy
+
+		if quote.RampType == RampTypeDeposit {
+			quote.Rate = rate.Sell
+		}
+	}
+
+	if quote.Rate <= 0 {
+		return nil, ErrRateNotFound
+	}
+
+	quote.Amount, quote.ConvertedAmount = req.Amount, req.Amount*quote.Rate
+	if req.LocalAmount > 0 {
+		quote.Amount, quote.ConvertedAmount = req.LocalAmount/quote.Rate, req.LocalAmount
+	}
+
+	if quote.ConvertedAmount < channel.Min || (channel.Max > 0 && quote.ConvertedAmount > float64(channel.Max)) {
+		return nil, fmt.Errorf("%w: %.2f %s is not between %.2f and %d",
+			ErrAmountOutOfRange, quote.ConvertedAmount, currency, channel.Min, channel.Max)
+	}
+
+	quote.FeeLocal, quote.FeeUSD = channel.FeeLocal, channel.FeeLocal/quote.Rate
+	if channel.FeeLocal == 0 {
+		quote.FeeLocal, quote.FeeUSD = channel.FeeUSD*quote.Rate, channel.FeeUSD
+	}
+
+	quote.Amount = roundAmount(quote.Amount)
+	quote.ConvertedAmount = roundAmount(quote.ConvertedAmount)
+	quote.FeeLocal = roundAmount(quote.FeeLocal)
+	quote.FeeUSD = roundAmount(quote.FeeUSD)
+	quote.TotalLocal = roundAmount(quote.ConvertedAmount + quote.FeeLocal)
+	quote.TotalUSD = roundAmount(quote.Amount + quote.FeeUSD)
+
+	return quote, nil
+}
+
+// roundAmount rounds an amount to two decimal places.
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
 // ResolveBankAccount validates a bank account before sending.
 func (cl *Client) ResolveBankAccount(
 	ctx context.Context,
 	req *ResolveBankAccountRequest,
-) (*ResolveBankAccountResponse, error) {
+) (_ *ResolveBankAccountResponse, err error) {
+	ctx, span := cl.startSpan(ctx, OperationResolveBankAccount, SpanAttributes{})
+	defer func() { span.end(nil, err) }()
+
 	payload, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("yellowcard: serialize request - %v", err)
@@ -287,7 +590,7 @@ func (cl *Client) ResolveBankAccount(
 
 	body := bytes.NewBuffer(payload)
 
-	resBody, err := cl.doPostRequest(ctx, "/business/details/bank", body)
+	resBody, err := cl.doPostRequest(ctx, OperationResolveBankAccount, "/business/details/bank", body)
 	if err != nil {
 		return nil, err
 	}
@@ -300,17 +603,73 @@ func (cl *Client) ResolveBankAccount(
 	return resp, nil
 }
 
+// ResolveMobileMoneyAccount validates a mobile money account against its Network before sending.
+func (cl *Client) ResolveMobileMoneyAccount(
+	ctx context.Context,
+	req *ResolveMobileMoneyAccountRequest,
+) (_ *ResolveMobileMoneyAccountResponse, err error) {
+	ctx, span := cl.startSpan(ctx, OperationResolveMobileMoneyAccount, SpanAttributes{})
+	defer func() { span.end(nil, err) }()
+
+	if !_mobileMoneyNumberRegex.MatchString(req.AccountNumber) {
+		return nil, ErrInvalidMobileMoneyNumber
+	}
+
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("yellowcard: serialize request - %v", err)
+	}
+
+	body := bytes.NewBuffer(payload)
+
+	resBody, err := cl.doPostRequest(ctx, OperationResolveMobileMoneyAccount, "/business/details/momo", body)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *ResolveMobileMoneyAccountResponse
+	if err = json.Unmarshal(resBody, &resp); err != nil {
+		return nil, fmt.Errorf("yellowcard: deserialize mobile money account response - %v", err)
+	}
+
+	return resp, nil
+}
+
 // MakePayment submits a disbursement payment request. This will lock in a rate and await approval.
 // Setting forceAccept field to true allows you to skip the accept payment request and your payment
 // will start processing once you submit payment request.
 // The amount has to be in USD and should be converted using the preferred Rate.
-func (cl *Client) MakePayment(ctx context.Context, req *PaymentRequest, forceAccept bool) (*Payment, error) {
+// Institution payments are sent with req.BusinessSender as the sender while retail payments use req.Sender.
+// See WithSafePaymentSubmit to recover from failures that leave the outcome of the submission unknown.
+func (cl *Client) MakePayment(
+	ctx context.Context,
+	req *PaymentRequest,
+	forceAccept bool,
+) (payment *Payment, err error) {
+	ctx, span := cl.startSpan(ctx, OperationMakePayment, SpanAttributes{SequenceID: req.SequenceID})
+	defer func() { span.end(payment, err) }()
+
 	req.ForceAccept = forceAccept
 
 	if req.CustomerType == "" {
 		req.CustomerType = CustomerTypeRetail
 	}
 
+	if req.CustomerType == CustomerTypeInstitution {
+		if req.BusinessSender == nil || req.BusinessSender.BusinessName == "" || req.BusinessSender.BusinessID == "" {
+			return nil, ErrBusinessSenderRequired
+		}
+	}
+
+	if cl.config.safeSubmitAttempts > 0 {
+		return cl.safeSubmitPayment(ctx, req)
+	}
+
+	return cl.submitPayment(ctx, req)
+}
+
+// submitPayment makes a single attempt at submitting the payment request.
+func (cl *Client) submitPayment(ctx context.Context, req *PaymentRequest) (*Payment, error) {
 	payload, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("yellowcard: serialize request - %v", err)
@@ -318,7 +677,7 @@ func (cl *Client) MakePayment(ctx context.Context, req *PaymentRequest, forceAcc
 
 	body := bytes.NewBuffer(payload)
 
-	resBody, err := cl.doPostRequest(ctx, "/business/payments", body)
+	resBody, err := cl.doPostRequest(ctx, OperationMakePayment, "/business/payments", body)
 	if err != nil {
 		return nil, err
 	}
@@ -332,18 +691,20 @@ func (cl *Client) MakePayment(ctx context.Context, req *PaymentRequest, forceAcc
 }
 
 // AcceptPaymentRequest accepts a payment request for execution.
-func (cl *Client) AcceptPaymentRequest(ctx context.Context, id string) (*Payment, error) {
+func (cl *Client) AcceptPaymentRequest(ctx context.Context, id string) (payment *Payment, err error) {
+	ctx, span := cl.startSpan(ctx, OperationAcceptPaymentRequest, SpanAttributes{PaymentID: id})
+	defer func() { span.end(payment, err) }()
+
 	var (
 		body = new(bytes.Buffer)
 		path = fmt.Sprintf("/business/payments/%s/accept", id)
 	)
 
-	resBody, err := cl.doPostRequest(ctx, path, body)
+	resBody, err := cl.doPostRequest(ctx, OperationAcceptPaymentRequest, path, body)
 	if err != nil {
 		return nil, err
 	}
 
-	var payment *Payment
 	if err = json.Unmarshal(resBody, &payment); err != nil {
 		return nil, fmt.Errorf("yellowcard: deserialize approve payment response - %v", err)
 	}
@@ -352,18 +713,20 @@ func (cl *Client) AcceptPaymentRequest(ctx context.Context, id string) (*Payment
 }
 
 // DenyPaymentRequest denys a payment request.
-func (cl *Client) DenyPaymentRequest(ctx context.Context, id string) (*Payment, error) {
+func (cl *Client) DenyPaymentRequest(ctx context.Context, id string) (payment *Payment, err error) {
+	ctx, span := cl.startSpan(ctx, OperationDenyPaymentRequest, SpanAttributes{PaymentID: id})
+	defer func() { span.end(payment, err) }()
+
 	var (
 		body = new(bytes.Buffer)
 		path = fmt.Sprintf("/business/payments/%s/deny", id)
 	)
 
-	resBody, err := cl.doPostRequest(ctx, path, body)
+	resBody, err := cl.doPostRequest(ctx, OperationDenyPaymentRequest, path, body)
 	if err != nil {
 		return nil, err
 	}
 
-	var payment *Payment
 	if err = json.Unmarshal(resBody, &payment); err != nil {
 		return nil, fmt.Errorf("yellowcard: deserialize deny payment response - %v", err)
 	}
@@ -372,15 +735,37 @@ func (cl *Client) DenyPaymentRequest(ctx context.Context, id string) (*Payment,
 }
 
 // LookupPayment retrieves information about a specific payment.
-func (cl *Client) LookupPayment(ctx context.Context, id string) (*Payment, error) {
+func (cl *Client) LookupPayment(ctx context.Context, id string) (payment *Payment, err error) {
+	ctx, span := cl.startSpan(ctx, OperationLookupPayment, SpanAttributes{PaymentID: id})
+	defer func() { span.end(payment, err) }()
+
 	path := fmt.Sprintf("/business/payments/%s", id)
 
-	resBody, err := cl.doGetRequest(ctx, path, nil)
+	resBody, err := cl.doGetRequest(ctx, OperationLookupPayment, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(resBody, &payment); err != nil {
+		return nil, fmt.Errorf("yellowcard: deserialize get payment response - %v", err)
+	}
+
+	return payment, nil
+}
+
+// LookupPaymentBySequenceID retrieves information about a specific payment using the sequence ID it was
+// submitted with.
+func (cl *Client) LookupPaymentBySequenceID(ctx context.Context, sequenceID string) (payment *Payment, err error) {
+	ctx, span := cl.startSpan(ctx, OperationLookupPaymentBySequenceID, SpanAttributes{SequenceID: sequenceID})
+	defer func() { span.end(payment, err) }()
+
+	path := fmt.Sprintf("/business/payments/sequence-id/%s", url.PathEscape(sequenceID))
+
+	resBody, err := cl.doGetRequest(ctx, OperationLookupPaymentBySequenceID, path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	var payment *Payment
 	if err = json.Unmarshal(resBody, &payment); err != nil {
 		return nil, fmt.Errorf("yellowcard: deserialize get payment response - %v", err)
 	}
@@ -388,17 +773,440 @@ func (cl *Client) LookupPayment(ctx context.Context, id string) (*Payment, error
 	return payment, nil
 }
 
-// New creates and initializes a new instance of API.
-func New(key string, secret string, opts ...func(*ClientConfig)) *Client {
+// ListPayments retrieves a single page of payments matching the filter.
+func (cl *Client) ListPayments(ctx context.Context, filter *ListPaymentsFilter) (_ []*Payment, err error) {
+	ctx, span := cl.startSpan(ctx, OperationListPayments, SpanAttributes{})
+	defer func() { span.end(nil, err) }()
+
+	if filter == nil {
+		filter = &ListPaymentsFilter{}
+	}
+
+	params := map[string]string{
+		"page":    strconv.Itoa(max(filter.Page, 1)),
+		"perPage": strconv.Itoa(perPage(filter.PerPage)),
+	}
+
+	if filter.ChannelID != "" {
+		params["channelId"] = filter.ChannelID
+	}
+
+	if !filter.EndDate.IsZero() {
+		params["endDate"] = filter.EndDate.UTC().Format(time.RFC3339)
+	}
+
+	if filter.SequenceID != "" {
+		params["sequenceId"] = filter.SequenceID
+	}
+
+	if !filter.StartDate.IsZero() {
+		params["startDate"] = filter.StartDate.UTC().Format(time.RFC3339)
+	}
+
+	if filter.Status != "" {
+		params["status"] = filter.Status
+	}
+
+	resBody, err := cl.doGetRequest(ctx, OperationListPayments, "/business/payments", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *PaymentsResponse
+	if err = json.Unmarshal(resBody, &resp); err != nil {
+		return nil, fmt.Errorf("yellowcard: deserialize list payments response - %v", err)
+	}
+
+	return resp.Payments, nil
+}
+
+// Payments returns an iterator over all payments matching the filter, fetching pages as they are consumed.
+// Iteration starts at filter.Page and stops at the first empty page or on the first error, which is yielded
+// alongside a nil payment. Short pages do not end the iteration as the API may cap the page size.
+func (cl *Client) Payments(ctx context.Context, filter *ListPaymentsFilter) iter.Seq2[*Payment, error] {
+	return func(yield func(*Payment, error) bool) {
+		f := ListPaymentsFilter{}
+		if filter != nil {
+			f = *filter
+		}
+
+		f.Page = max(f.Page, 1)
+		f.PerPage = perPage(f.PerPage)
+
+		for {
+			payments, err := cl.ListPayments(ctx, &f)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			if len(payments) == 0 {
+				return
+			}
+
+			for _, payment := range payments {
+				if !yield(payment, nil) {
+					return
+				}
+			}
+
+			f.Page++
+		}
+	}
+}
+
+// perPage returns n if it is a valid page size, otherwise DefaultPerPage.
+func perPage(n int) int {
+	if n <= 0 {
+		return DefaultPerPage
+	}
+
+	return n
+}
+
+// SubmitCollectionRequest submits a collection request. This will lock in a rate and await approval.
+// Setting forceAccept field to true allows you to skip the accept collection request and your collection
+// will start processing once you submit collection request.
+func (cl *Client) SubmitCollectionRequest(
+	ctx context.Context,
+	req *CollectionRequest,
+	forceAccept bool,
+) (collection *Collection, err error) {
+	ctx, span := cl.startSpan(ctx, OperationSubmitCollectionRequest, SpanAttributes{SequenceID: req.SequenceID})
+	defer func() { span.end(collection, err) }()
+
+	req.ForceAccept = forceAccept
+
+	if req.CustomerType == "" {
+		req.CustomerType = CustomerTypeRetail
+	}
+
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("yellowcard: serialize request - %v", err)
+	}
+
+	body := bytes.NewBuffer(payload)
+
+	resBody, err := cl.doPostRequest(ctx, OperationSubmitCollectionRequest, "/business/collections", body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(resBody, &collection); err != nil {
+		return nil, fmt.Errorf("yellowcard: deserialize submit collection response - %v", err)
+	}
+
+	return collection, nil
+}
+
+// AcceptCollectionRequest accepts a collection request for execution.
+func (cl *Client) AcceptCollectionRequest(ctx context.Context, id string) (collection *Collection, err error) {
+	ctx, span := cl.startSpan(ctx, OperationAcceptCollectionRequest, SpanAttributes{CollectionID: id})
+	defer func() { span.end(collection, err) }()
+
+	var (
+		body = new(bytes.Buffer)
+		path = fmt.Sprintf("/business/collections/%s/accept", id)
+	)
+
+	resBody, err := cl.doPostRequest(ctx, OperationAcceptCollectionRequest, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(resBody, &collection); err != nil {
+		return nil, fmt.Errorf("yellowcard: deserialize approve collection response - %v", err)
+	}
+
+	return collection, nil
+}
+
+// DenyCollectionRequest denys a collection request.
+func (cl *Client) DenyCollectionRequest(ctx context.Context, id string) (collection *Collection, err error) {
+	ctx, span := cl.startSpan(ctx, OperationDenyCollectionRequest, SpanAttributes{CollectionID: id})
+	defer func() { span.end(collection, err) }()
+
+	var (
+		body = new(bytes.Buffer)
+		path = fmt.Sprintf("/business/collections/%s/deny", id)
+	)
+
+	resBody, err := cl.doPostRequest(ctx, OperationDenyCollectionRequest, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(resBody, &collection); err != nil {
+		return nil, fmt.Errorf("yellowcard: deserialize deny collection response - %v", err)
+	}
+
+	return collection, nil
+}
+
+// LookupCollection retrieves information about a specific collection.
+func (cl *Client) LookupCollection(ctx context.Context, id string) (collection *Collection, err error) {
+	ctx, span := cl.startSpan(ctx, OperationLookupCollection, SpanAttributes{CollectionID: id})
+	defer func() { span.end(collection, err) }()
+
+	path := fmt.Sprintf("/business/collections/%s", id)
+
+	resBody, err := cl.doGetRequest(ctx, OperationLookupCollection, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(resBody, &collection); err != nil {
+		return nil, fmt.Errorf("yellowcard: deserialize get collection response - %v", err)
+	}
+
+	return collection, nil
+}
+
+// LookupCollectionBySequenceID retrieves information about a specific collection using the sequence ID it
+// was submitted with.
+func (cl *Client) LookupCollectionBySequenceID(
+	ctx context.Context,
+	sequenceID string,
+) (collection *Collection, err error) {
+	ctx, span := cl.startSpan(ctx, OperationLookupCollectionBySequenceID, SpanAttributes{SequenceID: sequenceID})
+	defer func() { span.end(collection, err) }()
+
+	path := fmt.Sprintf("/business/collections/sequence-id/%s", url.PathEscape(sequenceID))
+
+	resBody, err := cl.doGetRequest(ctx, OperationLookupCollectionBySequenceID, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(resBody, &collection); err != nil {
+		return nil, fmt.Errorf("yellowcard: deserialize get collection response - %v", err)
+	}
+
+	return collection, nil
+}
+
+// GetAccount retrieves the business account details along with the balance held in each currency.
+func (cl *Client) GetAccount(ctx context.Context) (_ *Account, err error) {
+	ctx, span := cl.startSpan(ctx, OperationGetAccount, SpanAttributes{})
+	defer func() { span.end(nil, err) }()
+
+	resBody, err := cl.doGetRequest(ctx, OperationGetAccount, "/business/account", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var account *Account
+	if err = json.Unmarshal(resBody, &account); err != nil {
+		return nil, fmt.Errorf("yellowcard: deserialize account response - %v", err)
+	}
+
+	return account, nil
+}
+
+// GetBalances retrieves the balance held in each currency by the business account.
+func (cl *Client) GetBalances(ctx context.Context) ([]*Balance, error) {
+	account, err := cl.GetAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return account.Balances, nil
+}
+
+// CreateSettlement submits a request to top up the business balance from, or withdraw it to, a stablecoin wallet.
+func (cl *Client) CreateSettlement(ctx context.Context, req *SettlementRequest) (_ *Settlement, err error) {
+	ctx, span := cl.startSpan(ctx, OperationCreateSettlement, SpanAttributes{})
+	defer func() { span.end(nil, err) }()
+
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("yellowcard: serialize request - %v", err)
+	}
+
+	body := bytes.NewBuffer(payload)
+
+	resBody, err := cl.doPostRequest(ctx, OperationCreateSettlement, "/business/settlements", body)
+	if err != nil {
+		return nil, err
+	}
+
+	var settlement *Settlement
+	if err = json.Unmarshal(resBody, &settlement); err != nil {
+		return nil, fmt.Errorf("yellowcard: deserialize create settlement response - %v", err)
+	}
+
+	return settlement, nil
+}
+
+// LookupSettlement retrieves information about a specific settlement.
+func (cl *Client) LookupSettlement(ctx context.Context, id string) (_ *Settlement, err error) {
+	ctx, span := cl.startSpan(ctx, OperationLookupSettlement, SpanAttributes{})
+	defer func() { span.end(nil, err) }()
+
+	path := fmt.Sprintf("/business/settlements/%s", id)
+
+	resBody, err := cl.doGetRequest(ctx, OperationLookupSettlement, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var settlement *Settlement
+	if err = json.Unmarshal(resBody, &settlement); err != nil {
+		return nil, fmt.Errorf("yellowcard: deserialize get settlement response - %v", err)
+	}
+
+	return settlement, nil
+}
+
+// ListSettlements retrieves a single page of settlements matching the filter.
+func (cl *Client) ListSettlements(ctx context.Context, filter *ListSettlementsFilter) (_ []*Settlement, err error) {
+	ctx, span := cl.startSpan(ctx, OperationListSettlements, SpanAttributes{})
+	defer func() { span.end(nil, err) }()
+
+	if filter == nil {
+		filter = &ListSettlementsFilter{}
+	}
+
+	params := map[string]string{
+		"page":    strconv.Itoa(max(filter.Page, 1)),
+		"perPage": strconv.Itoa(perPage(filter.PerPage)),
+	}
+
+	if filter.Status != "" {
+		params["status"] = filter.Status
+	}
+
+	if filter.Type != "" {
+		params["type"] = string(filter.Type)
+	}
+
+	resBody, err := cl.doGetRequest(ctx, OperationListSettlements, "/business/settlements", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *SettlementsResponse
+	if err = json.Unmarshal(resBody, &resp); err != nil {
+		return nil, fmt.Errorf("yellowcard: deserialize list settlements response - %v", err)
+	}
+
+	return resp.Settlements, nil
+}
+
+// CreateWebhook registers a URL to receive event notifications.
+func (cl *Client) CreateWebhook(ctx context.Context, req *WebhookRequest) (_ *Webhook, err error) {
+	ctx, span := cl.startSpan(ctx, OperationCreateWebhook, SpanAttributes{})
+	defer func() { span.end(nil, err) }()
+
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("yellowcard: serialize request - %v", err)
+	}
+
+	body := bytes.NewBuffer(payload)
+
+	resBody, err := cl.doPostRequest(ctx, OperationCreateWebhook, "/business/webhooks", body)
+	if err != nil {
+		return nil, err
+	}
+
+	var webhook *Webhook
+	if err = json.Unmarshal(resBody, &webhook); err != nil {
+		return nil, fmt.Errorf("yellowcard: deserialize create webhook response - %v", err)
+	}
+
+	return webhook, nil
+}
+
+// ListWebhooks retrieves all webhooks registered by the business.
+func (cl *Client) ListWebhooks(ctx context.Context) (_ []*Webhook, err error) {
+	ctx, span := cl.startSpan(ctx, OperationListWebhooks, SpanAttributes{})
+	defer func() { span.end(nil, err) }()
+
+	resBody, err := cl.doGetRequest(ctx, OperationListWebhooks, "/business/webhooks", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *WebhooksResponse
+	if err = json.Unmarshal(resBody, &resp); err != nil {
+		return nil, fmt.Errorf("yellowcard: deserialize webhooks response - %v", err)
+	}
+
+	return resp.Webhooks, nil
+}
+
+// UpdateWebhook replaces the URL, events and state of an existing webhook.
+func (cl *Client) UpdateWebhook(ctx context.Context, id string, req *WebhookRequest) (_ *Webhook, err error) {
+	ctx, span := cl.startSpan(ctx, OperationUpdateWebhook, SpanAttributes{})
+	defer func() { span.end(nil, err) }()
+
+	payload, err := json.Marshal(struct {
+		ID string `json:"id"`
+		*WebhookRequest
+	}{ID: id, WebhookRequest: req})
+	if err != nil {
+		return nil, fmt.Errorf("yellowcard: serialize request - %v", err)
+	}
+
+	body := bytes.NewBuffer(payload)
+
+	resBody, err := cl.doPutRequest(ctx, OperationUpdateWebhook, "/business/webhooks", body)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated *Webhook
+	if err = json.Unmarshal(resBody, &updated); err != nil {
+		return nil, fmt.Errorf("yellowcard: deserialize update webhook response - %v", err)
+	}
+
+	return updated, nil
+}
+
+// RemoveWebhook deletes a webhook so that it no longer receives event notifications.
+func (cl *Client) RemoveWebhook(ctx context.Context, id string) (err error) {
+	ctx, span := cl.startSpan(ctx, OperationRemoveWebhook, SpanAttributes{})
+	defer func() { span.end(nil, err) }()
+
+	path := fmt.Sprintf("/business/webhooks/%s", id)
+
+	_, err = cl.doDeleteRequest(ctx, OperationRemoveWebhook, path)
+	return err
+}
+
+// New creates and initializes a new instance of API. An error is returned if the key or secret is empty or
+// if any of the options is invalid. The key and secret are ignored when WithCredentialsProvider is set.
+func New(key string, secret string, opts ...func(*ClientConfig)) (*Client, error) {
 	config := DefaultConfig()
 
 	for _, opt := range opts {
 		opt(config)
 	}
 
-	return &Client{
-		config: config,
-		key:    key,
-		secret: secret,
+	var err error
+	if config.credentials == nil {
+		if strings.TrimSpace(key) == "" {
+			err = errors.Join(err, ErrKeyRequired)
+		}
+
+		if strings.TrimSpace(secret) == "" {
+			err = errors.Join(err, ErrSecretRequired)
+		}
+
+		config.credentials = NewStaticCredentials(key, secret)
+	}
+
+	if err = errors.Join(err, config.err); err != nil {
+		return nil, err
+	}
+
+	if config.customBaseURL != "" {
+		config.baseURL = config.customBaseURL
 	}
+
+	cl := &Client{config: config}
+	cl.handler = chain(cl.do, config.middleware)
+	return cl, nil
 }
@@ -2,13 +2,27 @@ package yellowcard
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"io"
 	"net/http"
 	"testing"
 	"time"
 )
 
+// newTestClient creates a Client with test credentials, failing the test if the options are invalid.
+func newTestClient(t *testing.T, opts ...func(*ClientConfig)) *Client {
+	t.Helper()
+
+	client, err := New("key", "secret", opts...)
+	if err != nil {
+		t.Fatalf("yellowcard: new client - %v", err)
+	}
+
+	return client
+}
+
 func TestDefaultConfig(t *testing.T) {
 	config := DefaultConfig()
 	assert.NotNil(t, config)
@@ -50,14 +64,11 @@ func TestClient_HttpAuthHeaders(t *testing.T) {
 		},
 	}
 
-	var (
-		fixedTimeUTC = time.Date(2024, time.June, 14, 16, 20, 0, 0, time.UTC)
-		client       = New("key", "secret")
-	)
+	fixedTimeUTC := time.Date(2024, time.June, 14, 16, 20, 0, 0, time.UTC)
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotHeaders := client.getHeaders(tt.method, tt.path, tt.body, fixedTimeUTC)
+			gotHeaders := signatureHeaders("key", "secret", tt.method, tt.path, tt.body, fixedTimeUTC)
 			assert.Equal(t, tt.want, gotHeaders)
 			assert.Len(t, gotHeaders, len(tt.want))
 		})
@@ -67,7 +78,7 @@ func TestClient_HttpAuthHeaders(t *testing.T) {
 func TestClient_GetChannels(t *testing.T) {
 	var (
 		httpClient = newMockHttpClient()
-		client     = New("key", "secret", WithHttpClient(httpClient))
+		client     = newTestClient(t, WithHttpClient(httpClient))
 		respBody   = `
 		{
 		   "channels":[
@@ -147,7 +158,7 @@ func TestClient_GetChannels(t *testing.T) {
 func TestClient_GetNetworks(t *testing.T) {
 	var (
 		httpClient = newMockHttpClient()
-		client     = New("key", "secret", WithHttpClient(httpClient))
+		client     = newTestClient(t, WithHttpClient(httpClient))
 		respBody   = `
 		{
 		   "networks":[
@@ -225,10 +236,171 @@ func TestClient_GetNetworks(t *testing.T) {
 	assert.Len(t, networks, 2)
 }
 
+func TestClient_ListChannels(t *testing.T) {
+	var (
+		httpClient = newMockHttpClient()
+		client     = newTestClient(t, WithHttpClient(httpClient))
+		respBody   = `
+		{
+		   "channels":[
+			  {
+				 "id":"79da4d6e-1c42-4aac-ae7d-422730528f96",
+				 "country":"KE",
+				 "status":"active",
+				 "apiStatus":"active",
+				 "channelType":"momo",
+				 "rampType":"deposit"
+			  },
+			  {
+				 "id":"402fd1e6-935e-45ff-a39d-2a5b7a57f2cc",
+				 "country":"KE",
+				 "status":"active",
+				 "apiStatus":"active",
+				 "channelType":"momo",
+				 "rampType":"withdraw"
+			  },
+			  {
+				 "id":"fe8f4989-3bf6-41ca-a621-ffe2bc127569",
+				 "country":"KE",
+				 "status":"active",
+				 "apiStatus":"inactive",
+				 "channelType":"bank",
+				 "rampType":"withdraw"
+			  },
+			  {
+				 "id":"81018280-e320-4c81-9b2f-6f636c2239d8",
+				 "country":"KE",
+				 "status":"inactive",
+				 "apiStatus":"inactive",
+				 "channelType":"momo",
+				 "rampType":"withdraw"
+			  }
+		   ]
+		}`
+	)
+
+	httpClient.MockRequest(client.config.baseURL+"/business/channels?country=KE", func() (status int, body string) {
+		return http.StatusOK, respBody
+	})
+
+	tests := []struct {
+		name string
+		opts *ChannelOptions
+		want []string
+	}{
+		{
+			name: "Tests active channels",
+			opts: &ChannelOptions{Country: CountryCodeKE},
+			want: []string{
+				"79da4d6e-1c42-4aac-ae7d-422730528f96",
+				"402fd1e6-935e-45ff-a39d-2a5b7a57f2cc",
+				"fe8f4989-3bf6-41ca-a621-ffe2bc127569",
+			},
+		},
+		{
+			name: "Tests withdraw mobile money channels",
+			opts: &ChannelOptions{
+				ChannelType: AccountTypeMobileMoney,
+				Country:     CountryCodeKE,
+				RampType:    RampTypeWithdraw,
+			},
+			want: []string{"402fd1e6-935e-45ff-a39d-2a5b7a57f2cc"},
+		},
+		{
+			name: "Tests inactive channels are included",
+			opts: &ChannelOptions{
+				ChannelType:     AccountTypeMobileMoney,
+				Country:         CountryCodeKE,
+				IncludeInactive: true,
+				RampType:        RampTypeWithdraw,
+			},
+			want: []string{"402fd1e6-935e-45ff-a39d-2a5b7a57f2cc", "81018280-e320-4c81-9b2f-6f636c2239d8"},
+		},
+		{
+			name: "Tests api status",
+			opts: &ChannelOptions{
+				APIStatus:       "inactive",
+				Country:         CountryCodeKE,
+				IncludeInactive: true,
+			},
+			want: []string{"fe8f4989-3bf6-41ca-a621-ffe2bc127569", "81018280-e320-4c81-9b2f-6f636c2239d8"},
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			channels, err := client.ListChannels(ctx, tt.opts)
+			assert.NoError(t, err)
+
+			var ids []string
+			for _, channel := range channels {
+				ids = append(ids, channel.ID)
+			}
+
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func TestClient_ListNetworks(t *testing.T) {
+	var (
+		httpClient = newMockHttpClient()
+		client     = newTestClient(t, WithHttpClient(httpClient))
+	)
+
+	httpClient.MockRequest(client.config.baseURL+"/business/networks", func() (status int, body string) {
+		return http.StatusOK, `
+		{
+		   "networks":[
+			  {
+				 "id":"41109c18-9604-4389-8472-44ff4378c6cb",
+				 "status":"active",
+				 "accountNumberType":"bank"
+			  },
+			  {
+				 "id":"5f8b4c29-6b24-45a4-9f8c-3c6a5a3b3a3e",
+				 "status":"active",
+				 "accountNumberType":"momo"
+			  },
+			  {
+				 "id":"0d19d67e-5946-4289-bac1-ad147d7c84ad",
+				 "status":"inactive",
+				 "accountNumberType":"momo"
+			  }
+		   ]
+		}`
+	})
+
+	ctx := context.Background()
+
+	networks, err := client.ListNetworks(ctx, nil)
+	assert.NoError(t, err)
+	assert.Len(t, networks, 2)
+
+	networks, err = client.ListNetworks(ctx, &NetworkOptions{AccountNumberType: AccountTypeMobileMoney})
+	assert.NoError(t, err)
+	assert.Len(t, networks, 1)
+	assert.Equal(t, "5f8b4c29-6b24-45a4-9f8c-3c6a5a3b3a3e", networks[0].ID)
+
+	networks, err = client.ListNetworks(ctx, &NetworkOptions{
+		AccountNumberType: AccountTypeMobileMoney,
+		IncludeInactive:   true,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, networks, 2)
+
+	// Test ensures country code is valid
+	networks, err = client.ListNetworks(ctx, &NetworkOptions{Country: "MARS"})
+	assert.EqualError(t, ErrCountryNotSupported, err.Error())
+	assert.Nil(t, networks)
+}
+
 func TestClient_GetRates(t *testing.T) {
 	var (
 		httpClient = newMockHttpClient()
-		client     = New("key", "secret", WithHttpClient(httpClient))
+		client     = newTestClient(t, WithHttpClient(httpClient))
 		respBody   = `
 		{
 		   "rates":[
@@ -300,10 +472,143 @@ func TestClient_GetRates(t *testing.T) {
 	assert.Equal(t, "TZ", rates[0].Locale)
 }
 
+func TestClient_Quote(t *testing.T) {
+	var (
+		httpClient = newMockHttpClient()
+		client     = newTestClient(t, WithHttpClient(httpClient))
+	)
+
+	httpClient.MockRequest(client.config.baseURL+"/business/channels?country=KE", func() (status int, body string) {
+		return http.StatusOK, `
+		{
+		   "channels":[
+			  {
+				 "id":"402fd1e6-935e-45ff-a39d-2a5b7a57f2cc",
+				 "country":"KE",
+				 "currency":"KES",
+				 "status":"active",
+				 "channelType":"momo",
+				 "rampType":"withdraw",
+				 "feeLocal":129.5,
+				 "feeUSD":1,
+				 "min":500,
+				 "max":150000
+			  },
+			  {
+				 "id":"79da4d6e-1c42-4aac-ae7d-422730528f96",
+				 "country":"KE",
+				 "currency":"KES",
+				 "status":"active",
+				 "channelType":"momo",
+				 "rampType":"deposit",
+				 "feeLocal":0,
+				 "feeUSD":0.5,
+				 "min":100,
+				 "max":150000
+			  }
+		   ]
+		}`
+	})
+
+	httpClient.MockRequest(client.config.baseURL+"/business/rates?currency=KES", func() (status int, body string) {
+		return http.StatusOK, `
+		{
+		   "rates":[
+			  {
+				 "buy":129,
+				 "sell":131,
+				 "locale":"KE",
+				 "rateId":"kenyan-shilling",
+				 "code":"KES",
+				 "updatedAt":"2024-06-10T13:52:24.739Z"
+			  }
+		   ]
+		}`
+	})
+
+	ctx := context.Background()
+
+	quote, err := client.Quote(ctx, &QuoteRequest{
+		Amount:    100,
+		ChannelID: "402fd1e6-935e-45ff-a39d-2a5b7a57f2cc",
+		Country:   CountryCodeKE,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &Quote{
+		Amount:          100,
+		ChannelID:       "402fd1e6-935e-45ff-a39d-2a5b7a57f2cc",
+		ConvertedAmount: 12900,
+		Currency:        CurrencyCodeKES,
+		FeeLocal:        129.5,
+		FeeUSD:          1,
+		RampType:        RampTypeWithdraw,
+		Rate:            129,
+		RateID:          "kenyan-shilling",
+		TotalLocal:      13029.5,
+		TotalUSD:        101,
+	}, quote)
+
+	// Test local amounts are converted to USD
+	quote, err = client.Quote(ctx, &QuoteRequest{
+		ChannelID:   "402fd1e6-935e-45ff-a39d-2a5b7a57f2cc",
+		Country:     CountryCodeKE,
+		LocalAmount: 12900,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, float64(100), quote.Amount)
+	assert.Equal(t, float64(12900), quote.ConvertedAmount)
+
+	// Test deposit channels use the sell rate and a USD fee is converted with it
+	quote, err = client.Quote(ctx, &QuoteRequest{
+		Amount:    10,
+		ChannelID: "79da4d6e-1c42-4aac-ae7d-422730528f96",
+		Country:   CountryCodeKE,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, float64(131), quote.Rate)
+	assert.Equal(t, 65.5, quote.FeeLocal)
+	assert.Equal(t, 0.5, quote.FeeUSD)
+	assert.Equal(t, 1375.5, quote.TotalLocal)
+
+	// Test amounts outside the channel limits
+	quote, err = client.Quote(ctx, &QuoteRequest{
+		Amount:    2000,
+		ChannelID: "402fd1e6-935e-45ff-a39d-2a5b7a57f2cc",
+		Country:   CountryCodeKE,
+	})
+
+	assert.ErrorIs(t, err, ErrAmountOutOfRange)
+	assert.Nil(t, quote)
+
+	// Test unknown channels
+	quote, err = client.Quote(ctx, &QuoteRequest{
+		Amount:    100,
+		ChannelID: "unknown",
+		Country:   CountryCodeKE,
+	})
+
+	assert.ErrorIs(t, err, ErrChannelNotFound)
+	assert.Nil(t, quote)
+
+	// Test ensures exactly one amount is set
+	quote, err = client.Quote(ctx, &QuoteRequest{
+		Amount:      100,
+		ChannelID:   "402fd1e6-935e-45ff-a39d-2a5b7a57f2cc",
+		Country:     CountryCodeKE,
+		LocalAmount: 12900,
+	})
+
+	assert.ErrorIs(t, err, ErrInvalidQuoteAmount)
+	assert.Nil(t, quote)
+}
+
 func TestClient_ResolveBankAccount(t *testing.T) {
 	var (
 		httpClient = newMockHttpClient()
-		client     = New("key", "secret", WithHttpClient(httpClient))
+		client     = newTestClient(t, WithHttpClient(httpClient))
 	)
 
 	httpClient.MockRequest(client.config.baseURL+"/business/details/bank", func() (status int, body string) {
@@ -328,10 +633,49 @@ func TestClient_ResolveBankAccount(t *testing.T) {
 	assert.Equal(t, "Ken Adams", bankAccountDetails.AccountName)
 }
 
+func TestClient_ResolveMobileMoneyAccount(t *testing.T) {
+	var (
+		httpClient = newMockHttpClient()
+		client     = newTestClient(t, WithHttpClient(httpClient))
+	)
+
+	httpClient.MockRequest(client.config.baseURL+"/business/details/momo", func() (status int, body string) {
+		return http.StatusOK, `
+		{
+		   "accountNumber":"+254700000000",
+		   "accountName":"Ken Adams",
+		   "networkName":"M-PESA"
+		}`
+	})
+
+	ctx := context.Background()
+
+	accountDetails, err := client.ResolveMobileMoneyAccount(ctx, &ResolveMobileMoneyAccountRequest{
+		AccountNumber: "+254700000000",
+		NetworkID:     "5f8b4c29-6b24-45a4-9f8c-3c6a5a3b3a3e",
+	})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, accountDetails)
+	assert.Equal(t, "+254700000000", accountDetails.AccountNumber)
+	assert.Equal(t, "Ken Adams", accountDetails.AccountName)
+	assert.Equal(t, "M-PESA", accountDetails.NetworkName)
+
+	// Test ensures the mobile money number is valid
+	accountDetails, err = client.ResolveMobileMoneyAccount(ctx, &ResolveMobileMoneyAccountRequest{
+		AccountNumber: "0700-000",
+		NetworkID:     "5f8b4c29-6b24-45a4-9f8c-3c6a5a3b3a3e",
+	})
+
+	assert.EqualError(t, ErrInvalidMobileMoneyNumber, err.Error())
+	assert.Nil(t, accountDetails)
+	assert.Len(t, httpClient.requests, 1)
+}
+
 func TestClient_MakePayment(t *testing.T) {
 	var (
 		httpClient = newMockHttpClient()
-		client     = New("key", "secret", WithHttpClient(httpClient))
+		client     = newTestClient(t, WithHttpClient(httpClient))
 		uri        = client.config.baseURL + "/business/payments"
 	)
 
@@ -414,10 +758,96 @@ func TestClient_MakePayment(t *testing.T) {
 	assert.Equal(t, paymentRequest.Destination.AccountBank, payment.Destination.AccountBank)
 }
 
+func TestClient_MakeInstitutionPayment(t *testing.T) {
+	var (
+		httpClient = newMockHttpClient()
+		client     = newTestClient(t, WithHttpClient(httpClient))
+		uri        = client.config.baseURL + "/business/payments"
+	)
+
+	httpClient.MockRequest(uri, func() (status int, body string) {
+		return http.StatusOK, `
+		{
+		   "amount":7491.65,
+		   "channelId":"81018280-e320-4c81-9b2f-6f636c2239d8",
+		   "id":"0aa5bd35-b969-5d1d-ae7b-dfc0c4abbaf7",
+		   "sender":{
+			  "address":"1 Sample Street",
+			  "businessId":"C.123456",
+			  "businessName":"Sample Holdings Ltd",
+			  "country":"US"
+		   },
+		   "sequenceId":"hQxWbXyEvq",
+		   "status":"created"
+		}`
+	})
+
+	var (
+		paymentRequest = &PaymentRequest{
+			Amount: 7491.65,
+			BusinessSender: &BusinessSender{
+				Address:      "1 Sample Street",
+				BusinessID:   "C.123456",
+				BusinessName: "Sample Holdings Ltd",
+				Country:      "US",
+			},
+			ChannelID:    "81018280-e320-4c81-9b2f-6f636c2239d8",
+			CustomerType: CustomerTypeInstitution,
+			Destination: Destination{
+				AccountName:   "Ken Adams",
+				AccountNumber: "+12222222222",
+				AccountType:   AccountTypeMobileMoney,
+				Country:       "ZA",
+				NetworkID:     "41109c18-9604-4389-8472-44ff4378c6cb",
+			},
+			Reason:     "invoice",
+			SequenceID: "hQxWbXyEvq",
+		}
+		ctx = context.Background()
+	)
+
+	payment, err := client.MakePayment(ctx, paymentRequest, true)
+	assert.NoError(t, err)
+	assert.NotNil(t, payment)
+	assert.NotNil(t, payment.BusinessSender)
+	assert.Equal(t, "Sample Holdings Ltd", payment.BusinessSender.BusinessName)
+
+	reqBody, err := io.ReadAll(httpClient.requests[0].Body)
+	assert.NoError(t, err)
+
+	var sent map[string]any
+	assert.NoError(t, json.Unmarshal(reqBody, &sent))
+	assert.Equal(t, "institution", sent["customerType"])
+	assert.Equal(t, map[string]any{
+		"address":      "1 Sample Street",
+		"businessId":   "C.123456",
+		"businessName": "Sample Holdings Ltd",
+		"country":      "US",
+	}, sent["sender"])
+
+	// Test retail payments serialize the retail sender
+	retailPayload, err := json.Marshal(&PaymentRequest{
+		BusinessSender: paymentRequest.BusinessSender,
+		CustomerType:   CustomerTypeRetail,
+		Sender:         Sender{Name: "Sample Name"},
+	})
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(retailPayload), `"name":"Sample Name"`)
+	assert.NotContains(t, string(retailPayload), "businessName")
+
+	// Test institution payments require the business sender
+	paymentRequest.BusinessSender = nil
+	payment, err = client.MakePayment(ctx, paymentRequest, true)
+	assert.EqualError(t, ErrBusinessSenderRequired, err.Error())
+	assert.Nil(t, payment)
+	assert.Len(t, httpClient.requests, 1)
+}
+
 func TestClient_AcceptPaymentRequest(t *testing.T) {
 	var (
 		httpClient = newMockHttpClient()
-		client     = New("key", "secret", WithHttpClient(httpClient))
+		client     = newTestClient(t, WithHttpClient(httpClient))
 		paymentID  = "d83011e8-341f-5e3e-b908-84cb4a552fcc"
 		uri        = fmt.Sprintf("%s/business/payments/%s/accept", client.config.baseURL, paymentID)
 	)
@@ -480,7 +910,7 @@ func TestClient_AcceptPaymentRequest(t *testing.T) {
 func TestClient_DenyPaymentRequest(t *testing.T) {
 	var (
 		httpClient = newMockHttpClient()
-		client     = New("key", "secret", WithHttpClient(httpClient))
+		client     = newTestClient(t, WithHttpClient(httpClient))
 		paymentID  = "c1de8da5-c11a-5cff-a17a-3e7c7085044c"
 		uri        = fmt.Sprintf("%s/business/payments/%s/deny", client.config.baseURL, paymentID)
 	)
@@ -543,7 +973,7 @@ func TestClient_DenyPaymentRequest(t *testing.T) {
 func TestClient_AcceptPaymentRequestInvalidState(t *testing.T) {
 	var (
 		httpClient = newMockHttpClient()
-		client     = New("key", "secret", WithHttpClient(httpClient))
+		client     = newTestClient(t, WithHttpClient(httpClient))
 		paymentID  = "c1de8da5-c11a-5cff-a17a-3e7c7085044c"
 		uri        = fmt.Sprintf("%s/business/payments/%s/accept", client.config.baseURL, paymentID)
 	)
@@ -568,7 +998,7 @@ func TestClient_AcceptPaymentRequestInvalidState(t *testing.T) {
 func TestClient_DeclinePaymentRequestInvalidState(t *testing.T) {
 	var (
 		httpClient = newMockHttpClient()
-		client     = New("key", "secret", WithHttpClient(httpClient))
+		client     = newTestClient(t, WithHttpClient(httpClient))
 		paymentID  = "c1de8da5-c11a-5cff-a17a-3e7c7085044c"
 		uri        = fmt.Sprintf("%s/business/payments/%s/deny", client.config.baseURL, paymentID)
 	)
@@ -593,7 +1023,7 @@ func TestClient_DeclinePaymentRequestInvalidState(t *testing.T) {
 func TestClient_LookupPayment(t *testing.T) {
 	var (
 		httpClient = newMockHttpClient()
-		client     = New("key", "secret", WithHttpClient(httpClient))
+		client     = newTestClient(t, WithHttpClient(httpClient))
 		paymentID  = "c1de8da5-c11a-5cff-a17a-3e7c7085044c"
 		uri        = fmt.Sprintf("%s/business/payments/%s", client.config.baseURL, paymentID)
 	)
@@ -656,7 +1086,7 @@ func TestClient_LookupPayment(t *testing.T) {
 func TestClient_LookupPaymentNotFound(t *testing.T) {
 	var (
 		httpClient = newMockHttpClient()
-		client     = New("key", "secret", WithHttpClient(httpClient))
+		client     = newTestClient(t, WithHttpClient(httpClient))
 		paymentID  = "c1de8da5-c11a-5cff-a17a-3e7c7085044c"
 		uri        = fmt.Sprintf("%s/business/payments/%s", client.config.baseURL, paymentID)
 	)
@@ -677,26 +1107,767 @@ func TestClient_LookupPaymentNotFound(t *testing.T) {
 	assert.Nil(t, payment)
 }
 
-func TestNewClient_WithOpts(t *testing.T) {
-	client := New("key", "secret")
-	assert.NotNil(t, client)
-	assert.Equal(t, "key", client.key)
-	assert.Equal(t, "secret", client.secret)
+func TestClient_LookupPaymentBySequenceID(t *testing.T) {
+	var (
+		httpClient = newMockHttpClient()
+		client     = newTestClient(t, WithHttpClient(httpClient))
+		sequenceID = "ZEmcaXRAPc"
+		uri        = fmt.Sprintf("%s/business/payments/sequence-id/%s", client.config.baseURL, sequenceID)
+	)
 
-	client = New("key", "secret", WithEnvironment(EnvironmentSandbox))
-	assert.Equal(t, EnvironmentSandbox, client.config.env)
-	assert.Equal(t, _sandboxBaseURL, client.config.baseURL)
+	httpClient.MockRequest(uri, func() (status int, body string) {
+		return http.StatusOK, `
+		{
+		   "id":"c1de8da5-c11a-5cff-a17a-3e7c7085044c",
+		   "sequenceId":"ZEmcaXRAPc",
+		   "status":"pending",
+		   "amount":7491.65,
+		   "currency":"ZAR",
+		   "country":"ZA"
+		}`
+	})
 
-	// Test unknown environment
-	client = New("key", "secret", WithEnvironment(Environment(2)))
-	assert.Equal(t, EnvironmentProduction, client.config.env)
-	assert.Equal(t, _prodBaseURL, client.config.baseURL)
+	ctx := context.Background()
 
-	withHttpClient := &http.Client{
-		Timeout: 10 * time.Second,
-	}
+	payment, err := client.LookupPaymentBySequenceID(ctx, sequenceID)
+	assert.NoError(t, err)
+	assert.NotNil(t, payment)
+	assert.Equal(t, "c1de8da5-c11a-5cff-a17a-3e7c7085044c", payment.ID)
+	assert.Equal(t, sequenceID, payment.SequenceID)
+
+	// Test sequence ID is escaped
+	payment, err = client.LookupPaymentBySequenceID(ctx, "order/42")
+	assert.Error(t, err)
+	assert.Nil(t, payment)
+	assert.Equal(t, "/business/payments/sequence-id/order%2F42", httpClient.requests[1].URL.EscapedPath())
+}
+
+func TestClient_ListPayments(t *testing.T) {
+	var (
+		httpClient = newMockHttpClient()
+		client     = newTestClient(t, WithHttpClient(httpClient))
+		uri        = client.config.baseURL + "/business/payments?channelId=81018280-e320-4c81-9b2f-6f636c2239d8" +
+			"&endDate=2024-06-16T00%3A00%3A00Z&page=1&perPage=50&sequenceId=nsahHJODjx" +
+			"&startDate=2024-06-15T00%3A00%3A00Z&status=complete"
+	)
+
+	httpClient.MockRequest(uri, func() (status int, body string) {
+		return http.StatusOK, `
+		{
+		   "payments":[
+			  {
+				 "id":"0aa5bd35-b969-5d1d-ae7b-dfc0c4abbaf7",
+				 "sequenceId":"nsahHJODjx",
+				 "channelId":"81018280-e320-4c81-9b2f-6f636c2239d8",
+				 "status":"complete",
+				 "amount":7491.65,
+				 "currency":"ZAR",
+				 "country":"ZA",
+				 "createdAt":"2024-06-15T06:54:25.576Z",
+				 "updatedAt":"2024-06-15T06:54:25.576Z"
+			  }
+		   ]
+		}`
+	})
+
+	ctx := context.Background()
+
+	payments, err := client.ListPayments(ctx, &ListPaymentsFilter{
+		ChannelID:  "81018280-e320-4c81-9b2f-6f636c2239d8",
+		EndDate:    time.Date(2024, time.June, 16, 0, 0, 0, 0, time.UTC),
+		SequenceID: "nsahHJODjx",
+		StartDate:  time.Date(2024, time.June, 15, 0, 0, 0, 0, time.UTC),
+		Status:     "complete",
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, payments, 1)
+	assert.Equal(t, "0aa5bd35-b969-5d1d-ae7b-dfc0c4abbaf7", payments[0].ID)
+	assert.Equal(t, "complete", payments[0].Status)
+
+	// Test unmatched filters surface the error response
+	payments, err = client.ListPayments(ctx, nil)
+	assert.Error(t, err)
+	assert.Nil(t, payments)
+}
+
+func TestClient_Payments(t *testing.T) {
+	var (
+		httpClient = newMockHttpClient()
+		client     = newTestClient(t, WithHttpClient(httpClient))
+		uri        = client.config.baseURL + "/business/payments?page=%d&perPage=2&status=complete"
+	)
+
+	httpClient.MockRequest(fmt.Sprintf(uri, 1), func() (status int, body string) {
+		return http.StatusOK, `{"payments":[{"id":"1","status":"complete"},{"id":"2","status":"complete"}]}`
+	})
+
+	// The server may return fewer payments than requested before the last page
+	httpClient.MockRequest(fmt.Sprintf(uri, 2), func() (status int, body string) {
+		return http.StatusOK, `{"payments":[{"id":"3","status":"complete"}]}`
+	})
+
+	httpClient.MockRequest(fmt.Sprintf(uri, 3), func() (status int, body string) {
+		return http.StatusOK, `{"payments":[{"id":"4","status":"complete"}]}`
+	})
+
+	httpClient.MockRequest(fmt.Sprintf(uri, 4), func() (status int, body string) {
+		return http.StatusOK, `{"payments":[]}`
+	})
+
+	var (
+		ctx    = context.Background()
+		filter = &ListPaymentsFilter{PerPage: 2, Status: "complete"}
+		ids    []string
+	)
+
+	for payment, err := range client.Payments(ctx, filter) {
+		assert.NoError(t, err)
+		ids = append(ids, payment.ID)
+	}
+
+	assert.Equal(t, []string{"1", "2", "3", "4"}, ids)
+	assert.Len(t, httpClient.requests, 4)
+
+	// Test breaking early stops fetching pages
+	ids = nil
+	for payment := range client.Payments(ctx, filter) {
+		ids = append(ids, payment.ID)
+		break
+	}
+
+	assert.Equal(t, []string{"1"}, ids)
+	assert.Len(t, httpClient.requests, 5)
+
+	// Test errors are yielded and stop the iteration
+	httpClient.MockRequest(fmt.Sprintf(uri, 2), func() (status int, body string) {
+		return http.StatusInternalServerError, `{"code":"InternalError","message":"something went wrong"}`
+	})
+
+	var errs []error
+	for _, err := range client.Payments(ctx, filter) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "InternalError")
+}
+
+func TestClient_SubmitCollectionRequest(t *testing.T) {
+	var (
+		httpClient   = newMockHttpClient()
+		client       = newTestClient(t, WithHttpClient(httpClient))
+		collectionID = "4f8a0b4e-3c6e-5f7a-9d2b-1e0c6a7b8d9f"
+		uri          = client.config.baseURL + "/business/collections"
+	)
+
+	httpClient.MockRequest(uri, func() (status int, body string) {
+		return http.StatusOK, fmt.Sprintf(`
+		{
+		   "id":"%s",
+		   "partnerId":"deb55c03-9961-417a-9550-f5ba7fe258e9",
+		   "sequenceId":"kKJmnTWuYz",
+		   "status":"%s",
+		   "amount":50,
+		   "convertedAmount":6450,
+		   "currency":"KES",
+		   "country":"KE",
+		   "rate":129,
+		   "channelId":"fe8f4989-3bf6-41ca-a621-ffe2bc127569",
+		   "customerType":"retail",
+		   "recipient":{
+			  "name":"Sample Name",
+			  "country":"KE",
+			  "phone":"+254700000000",
+			  "address":"Sample Address",
+			  "dob":"10/10/1950",
+			  "email":"email@domain.com",
+			  "idNumber":"0123456789",
+			  "idType":"license"
+		   },
+		   "source":{
+			  "accountType":"momo",
+			  "accountNumber":"+254700000000",
+			  "networkId":"5f8b4c29-6b24-45a4-9f8c-3c6a5a3b3a3e"
+		   },
+		   "forceAccept":false,
+		   "requestSource":"api",
+		   "directSettlement":false,
+		   "settlementInfo":{},
+		   "expiresAt":"2024-06-15T07:19:36.607Z",
+		   "createdAt":"2024-06-15T07:09:36.607Z",
+		   "updatedAt":"2024-06-15T07:09:36.607Z"
+		}`, collectionID, "created")
+	})
+
+	var (
+		collectionRequest = &CollectionRequest{
+			Amount:    50,
+			ChannelID: "fe8f4989-3bf6-41ca-a621-ffe2bc127569",
+			Recipient: Recipient{
+				Address:  "Sample Address",
+				Country:  "KE",
+				Dob:      "10/10/1950",
+				Email:    "email@domain.com",
+				IDNumber: "0123456789",
+				IDType:   "license",
+				Name:     "Sample Name",
+				Phone:    "+254700000000",
+			},
+			SequenceID: "kKJmnTWuYz",
+			Source: Source{
+				AccountNumber: "+254700000000",
+				AccountType:   AccountTypeMobileMoney,
+				NetworkID:     "5f8b4c29-6b24-45a4-9f8c-3c6a5a3b3a3e",
+			},
+		}
+		ctx = context.Background()
+	)
+
+	collection, err := client.SubmitCollectionRequest(ctx, collectionRequest, false)
+	assert.NoError(t, err)
+	assert.NotNil(t, collection)
+	assert.Equal(t, CustomerTypeRetail, collectionRequest.CustomerType)
+	assert.Equal(t, collectionRequest.Amount, collection.Amount)
+	assert.Equal(t, collectionRequest.SequenceID, collection.SequenceID)
+	assert.Equal(t, AccountTypeMobileMoney, collection.Source.AccountType)
+}
+
+func TestClient_AcceptCollectionRequest(t *testing.T) {
+	var (
+		httpClient   = newMockHttpClient()
+		client       = newTestClient(t, WithHttpClient(httpClient))
+		collectionID = "4f8a0b4e-3c6e-5f7a-9d2b-1e0c6a7b8d9f"
+		uri          = fmt.Sprintf("%s/business/collections/%s/accept", client.config.baseURL, collectionID)
+	)
+
+	httpClient.MockRequest(uri, func() (status int, body string) {
+		return http.StatusOK, fmt.Sprintf(`
+		{
+		   "id":"%s",
+		   "partnerId":"deb55c03-9961-417a-9550-f5ba7fe258e9",
+		   "sequenceId":"kKJmnTWuYz",
+		   "status":"%s",
+		   "amount":50,
+		   "convertedAmount":6450,
+		   "currency":"KES",
+		   "country":"KE",
+		   "rate":129,
+		   "channelId":"fe8f4989-3bf6-41ca-a621-ffe2bc127569",
+		   "customerType":"retail",
+		   "recipient":{
+			  "name":"Sample Name",
+			  "country":"KE",
+			  "phone":"+254700000000",
+			  "address":"Sample Address",
+			  "dob":"10/10/1950",
+			  "email":"email@domain.com",
+			  "idNumber":"0123456789",
+			  "idType":"license"
+		   },
+		   "source":{
+			  "accountType":"momo",
+			  "accountNumber":"+254700000000",
+			  "networkId":"5f8b4c29-6b24-45a4-9f8c-3c6a5a3b3a3e"
+		   },
+		   "forceAccept":false,
+		   "requestSource":"api",
+		   "directSettlement":false,
+		   "settlementInfo":{},
+		   "expiresAt":"2024-06-15T07:19:36.607Z",
+		   "createdAt":"2024-06-15T07:09:36.607Z",
+		   "updatedAt":"2024-06-15T07:09:36.607Z"
+		}`, collectionID, "process")
+	})
+
+	ctx := context.Background()
+
+	collection, err := client.AcceptCollectionRequest(ctx, collectionID)
+	assert.NoError(t, err)
+	assert.NotNil(t, collection)
+	assert.Equal(t, collectionID, collection.ID)
+	assert.Equal(t, "process", collection.Status)
+}
+
+func TestClient_DenyCollectionRequest(t *testing.T) {
+	var (
+		httpClient   = newMockHttpClient()
+		client       = newTestClient(t, WithHttpClient(httpClient))
+		collectionID = "4f8a0b4e-3c6e-5f7a-9d2b-1e0c6a7b8d9f"
+		uri          = fmt.Sprintf("%s/business/collections/%s/deny", client.config.baseURL, collectionID)
+	)
+
+	httpClient.MockRequest(uri, func() (status int, body string) {
+		return http.StatusOK, fmt.Sprintf(`
+		{
+		   "id":"%s",
+		   "partnerId":"deb55c03-9961-417a-9550-f5ba7fe258e9",
+		   "sequenceId":"kKJmnTWuYz",
+		   "status":"%s",
+		   "amount":50,
+		   "convertedAmount":6450,
+		   "currency":"KES",
+		   "country":"KE",
+		   "rate":129,
+		   "channelId":"fe8f4989-3bf6-41ca-a621-ffe2bc127569",
+		   "customerType":"retail",
+		   "recipient":{
+			  "name":"Sample Name",
+			  "country":"KE",
+			  "phone":"+254700000000",
+			  "address":"Sample Address",
+			  "dob":"10/10/1950",
+			  "email":"email@domain.com",
+			  "idNumber":"0123456789",
+			  "idType":"license"
+		   },
+		   "source":{
+			  "accountType":"momo",
+			  "accountNumber":"+254700000000",
+			  "networkId":"5f8b4c29-6b24-45a4-9f8c-3c6a5a3b3a3e"
+		   },
+		   "forceAccept":false,
+		   "requestSource":"api",
+		   "directSettlement":false,
+		   "settlementInfo":{},
+		   "expiresAt":"2024-06-15T07:19:36.607Z",
+		   "createdAt":"2024-06-15T07:09:36.607Z",
+		   "updatedAt":"2024-06-15T07:09:36.607Z"
+		}`, collectionID, "denied")
+	})
+
+	ctx := context.Background()
+
+	collection, err := client.DenyCollectionRequest(ctx, collectionID)
+	assert.NoError(t, err)
+	assert.NotNil(t, collection)
+	assert.Equal(t, collectionID, collection.ID)
+	assert.Equal(t, "denied", collection.Status)
+}
+
+func TestClient_AcceptCollectionRequestInvalidState(t *testing.T) {
+	var (
+		httpClient   = newMockHttpClient()
+		client       = newTestClient(t, WithHttpClient(httpClient))
+		collectionID = "4f8a0b4e-3c6e-5f7a-9d2b-1e0c6a7b8d9f"
+		uri          = fmt.Sprintf("%s/business/collections/%s/accept", client.config.baseURL, collectionID)
+	)
+
+	httpClient.MockRequest(uri, func() (status int, body string) {
+		return http.StatusBadRequest, `
+		{
+		   "code":"CollectionInvalidState",
+		   "message":"collection is not in pending_approval state"
+		}`
+	})
+
+	ctx := context.Background()
+
+	collection, err := client.AcceptCollectionRequest(ctx, collectionID)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "CollectionInvalidState")
+	assert.Nil(t, collection)
+}
+
+func TestClient_LookupCollection(t *testing.T) {
+	var (
+		httpClient   = newMockHttpClient()
+		client       = newTestClient(t, WithHttpClient(httpClient))
+		collectionID = "4f8a0b4e-3c6e-5f7a-9d2b-1e0c6a7b8d9f"
+		uri          = fmt.Sprintf("%s/business/collections/%s", client.config.baseURL, collectionID)
+	)
+
+	httpClient.MockRequest(uri, func() (status int, body string) {
+		return http.StatusOK, fmt.Sprintf(`
+		{
+		   "id":"%s",
+		   "partnerId":"deb55c03-9961-417a-9550-f5ba7fe258e9",
+		   "sequenceId":"kKJmnTWuYz",
+		   "status":"%s",
+		   "amount":50,
+		   "convertedAmount":6450,
+		   "currency":"KES",
+		   "country":"KE",
+		   "rate":129,
+		   "channelId":"fe8f4989-3bf6-41ca-a621-ffe2bc127569",
+		   "customerType":"retail",
+		   "recipient":{
+			  "name":"Sample Name",
+			  "country":"KE",
+			  "phone":"+254700000000",
+			  "address":"Sample Address",
+			  "dob":"10/10/1950",
+			  "email":"email@domain.com",
+			  "idNumber":"0123456789",
+			  "idType":"license"
+		   },
+		   "source":{
+			  "accountType":"momo",
+			  "accountNumber":"+254700000000",
+			  "networkId":"5f8b4c29-6b24-45a4-9f8c-3c6a5a3b3a3e"
+		   },
+		   "forceAccept":false,
+		   "requestSource":"api",
+		   "directSettlement":false,
+		   "settlementInfo":{},
+		   "expiresAt":"2024-06-15T07:19:36.607Z",
+		   "createdAt":"2024-06-15T07:09:36.607Z",
+		   "updatedAt":"2024-06-15T07:09:36.607Z"
+		}`, collectionID, "complete")
+	})
+
+	ctx := context.Background()
+
+	collection, err := client.LookupCollection(ctx, collectionID)
+	assert.NoError(t, err)
+	assert.NotNil(t, collection)
+	assert.Equal(t, collectionID, collection.ID)
+	assert.Equal(t, "complete", collection.Status)
+	assert.Equal(t, "KE", collection.Recipient.Country)
+}
+
+func TestClient_LookupCollectionBySequenceID(t *testing.T) {
+	var (
+		httpClient = newMockHttpClient()
+		client     = newTestClient(t, WithHttpClient(httpClient))
+		sequenceID = "kKJmnTWuYz"
+		uri        = fmt.Sprintf("%s/business/collections/sequence-id/%s", client.config.baseURL, sequenceID)
+	)
+
+	httpClient.MockRequest(uri, func() (status int, body string) {
+		return http.StatusNotFound, `
+		{
+		   "code":"CollectionNotFound",
+		   "message":"collection not found"
+		}`
+	})
+
+	ctx := context.Background()
+
+	collection, err := client.LookupCollectionBySequenceID(ctx, sequenceID)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "CollectionNotFound")
+	assert.Nil(t, collection)
+
+	httpClient.MockRequest(uri, func() (status int, body string) {
+		return http.StatusOK, `{"id":"4f8a0b4e-3c6e-5f7a-9d2b-1e0c6a7b8d9f","sequenceId":"kKJmnTWuYz","status":"pending"}`
+	})
+
+	collection, err = client.LookupCollectionBySequenceID(ctx, sequenceID)
+	assert.NoError(t, err)
+	assert.NotNil(t, collection)
+	assert.Equal(t, sequenceID, collection.SequenceID)
+}
+
+func TestClient_GetAccount(t *testing.T) {
+	var (
+		httpClient = newMockHttpClient()
+		client     = newTestClient(t, WithHttpClient(httpClient))
+	)
+
+	httpClient.MockRequest(client.config.baseURL+"/business/account", func() (status int, body string) {
+		return http.StatusOK, `
+		{
+		   "id":"deb55c03-9961-417a-9550-f5ba7fe258e9",
+		   "name":"Sample Business",
+		   "status":"active",
+		   "balances":[
+			  {
+				 "currency":"USD",
+				 "available":25000.5,
+				 "pending":1200
+			  },
+			  {
+				 "currency":"USDT",
+				 "available":1000,
+				 "pending":0
+			  }
+		   ],
+		   "createdAt":"2024-01-10T09:36:35.818Z",
+		   "updatedAt":"2024-06-15T07:41:24.624Z"
+		}`
+	})
+
+	ctx := context.Background()
+
+	account, err := client.GetAccount(ctx)
+	assert.NoError(t, err)
+	assert.NotNil(t, account)
+	assert.Equal(t, "deb55c03-9961-417a-9550-f5ba7fe258e9", account.ID)
+	assert.Len(t, account.Balances, 2)
+	assert.Equal(t, 25000.5, account.Balance("usd").Available)
+	assert.Nil(t, account.Balance("EUR"))
+
+	balances, err := client.GetBalances(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, balances, 2)
+	assert.Equal(t, "USDT", balances[1].Currency)
+	assert.Equal(t, float64(1000), balances[1].Available)
+}
+
+func TestClient_Settlements(t *testing.T) {
+	var (
+		httpClient   = newMockHttpClient()
+		client       = newTestClient(t, WithHttpClient(httpClient))
+		settlementID = "7c2e5b1a-9f4d-4e3a-8b6c-1d0f2a3b4c5d"
+		uri          = client.config.baseURL + "/business/settlements"
+		respBody     = `
+		{
+		   "id":"7c2e5b1a-9f4d-4e3a-8b6c-1d0f2a3b4c5d",
+		   "partnerId":"deb55c03-9961-417a-9550-f5ba7fe258e9",
+		   "sequenceId":"topup-2024-06-15",
+		   "type":"topup",
+		   "status":"%s",
+		   "amount":10000,
+		   "amountUSD":10000,
+		   "cryptoCurrency":"USDT",
+		   "cryptoNetwork":"TRC20",
+		   "walletAddress":"TXYZopYRdj2D9XRtbG411XZZ3kM5VkAeBf",
+		   "depositAddress":"TQn9Y2khEsLJW1ChVWFMSMeRDow5KcbLSE",
+		   "createdAt":"2024-06-15T07:09:36.607Z",
+		   "updatedAt":"2024-06-15T07:09:36.607Z",
+		   "expiresAt":"2024-06-15T08:09:36.607Z"
+		}`
+		ctx = context.Background()
+	)
+
+	httpClient.MockRequest(uri, func() (status int, body string) {
+		return http.StatusOK, fmt.Sprintf(respBody, "pending")
+	})
+
+	settlement, err := client.CreateSettlement(ctx, &SettlementRequest{
+		Amount:         10000,
+		CryptoCurrency: "USDT",
+		CryptoNetwork:  CryptoNetworkTRC20,
+		SequenceID:     "topup-2024-06-15",
+		Type:           SettlementTypeTopUp,
+		WalletAddress:  "TXYZopYRdj2D9XRtbG411XZZ3kM5VkAeBf",
+	})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, settlement)
+	assert.Equal(t, settlementID, settlement.ID)
+	assert.Equal(t, CryptoNetworkTRC20, settlement.CryptoNetwork)
+	assert.Equal(t, SettlementTypeTopUp, settlement.Type)
+	assert.Equal(t, "TQn9Y2khEsLJW1ChVWFMSMeRDow5KcbLSE", settlement.DepositAddress)
+
+	httpClient.MockRequest(uri+"/"+settlementID, func() (status int, body string) {
+		return http.StatusOK, fmt.Sprintf(respBody, "complete")
+	})
+
+	settlement, err = client.LookupSettlement(ctx, settlementID)
+	assert.NoError(t, err)
+	assert.Equal(t, "complete", settlement.Status)
+
+	httpClient.MockRequest(uri+"?page=1&perPage=50&type=topup", func() (status int, body string) {
+		return http.StatusOK, fmt.Sprintf(`{"settlements":[`+respBody+`]}`, "complete")
+	})
+
+	settlements, err := client.ListSettlements(ctx, &ListSettlementsFilter{Type: SettlementTypeTopUp})
+	assert.NoError(t, err)
+	assert.Len(t, settlements, 1)
+	assert.Equal(t, settlementID, settlements[0].ID)
+}
+
+func TestPayment_SettlementInfo(t *testing.T) {
+	var payment *Payment
+
+	err := json.Unmarshal([]byte(`
+	{
+	   "id":"0aa5bd35-b969-5d1d-ae7b-dfc0c4abbaf7",
+	   "directSettlement":true,
+	   "settlementInfo":{
+		  "cryptoAmount":7491.65,
+		  "cryptoCurrency":"USDC",
+		  "cryptoNetwork":"POLYGON",
+		  "txHash":"0x9c1f6a3b",
+		  "walletAddress":"0x2b5AD5c4795c026514f8317c7a215E218DcCD6cF"
+	   }
+	}`), &payment)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, payment.SettlementInfo)
+	assert.Equal(t, CryptoNetworkPolygon, payment.SettlementInfo.CryptoNetwork)
+	assert.Equal(t, "USDC", payment.SettlementInfo.CryptoCurrency)
+	assert.Equal(t, 7491.65, payment.SettlementInfo.CryptoAmount)
+}
+
+func TestClient_Webhooks(t *testing.T) {
+	var (
+		httpClient = newMockHttpClient()
+		client     = newTestClient(t, WithHttpClient(httpClient))
+		webhookID  = "b1f3a4b8-5d3c-4c1e-9f0a-2c5b7e9d1a3f"
+		uri        = client.config.baseURL + "/business/webhooks"
+		respBody   = `
+		{
+		   "id":"b1f3a4b8-5d3c-4c1e-9f0a-2c5b7e9d1a3f",
+		   "partnerId":"deb55c03-9961-417a-9550-f5ba7fe258e9",
+		   "url":"https://example.com/webhooks/yellowcard",
+		   "events":["PAYMENT.COMPLETE"],
+		   "active":%t,
+		   "createdAt":"2024-06-15T07:09:36.607Z",
+		   "updatedAt":"2024-06-15T07:09:36.607Z"
+		}`
+		ctx = context.Background()
+	)
+
+	httpClient.MockRequest(uri, func() (status int, body string) {
+		return http.StatusOK, fmt.Sprintf(respBody, true)
+	})
+
+	webhook, err := client.CreateWebhook(ctx, &WebhookRequest{
+		Active: true,
+		Events: []EventType{EventPaymentComplete},
+		URL:    "https://example.com/webhooks/yellowcard",
+	})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, webhook)
+	assert.Equal(t, webhookID, webhook.ID)
+	assert.True(t, webhook.Active)
+	assert.Equal(t, []EventType{EventPaymentComplete}, webhook.Events)
+
+	req := httpClient.requests[0]
+	assert.Equal(t, http.MethodPost, req.Method)
+	assert.Contains(t, req.Header.Get("Authorization"), "YcHmacV1 key:")
+
+	httpClient.MockRequest(uri, func() (status int, body string) {
+		return http.StatusOK, fmt.Sprintf(`{"webhooks":[`+respBody+`]}`, true)
+	})
+
+	webhooks, err := client.ListWebhooks(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, webhooks, 1)
+	assert.Equal(t, webhookID, webhooks[0].ID)
+
+	httpClient.MockRequest(uri, func() (status int, body string) {
+		return http.StatusOK, fmt.Sprintf(respBody, false)
+	})
+
+	webhook, err = client.UpdateWebhook(ctx, webhookID, &WebhookRequest{
+		Active: false,
+		Events: []EventType{EventPaymentComplete},
+		URL:    "https://example.com/webhooks/yellowcard",
+	})
+
+	assert.NoError(t, err)
+	assert.False(t, webhook.Active)
+
+	req = httpClient.requests[2]
+	assert.Equal(t, http.MethodPut, req.Method)
+
+	reqBody, err := io.ReadAll(req.Body)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `
+	{
+	   "id":"b1f3a4b8-5d3c-4c1e-9f0a-2c5b7e9d1a3f",
+	   "active":false,
+	   "events":["PAYMENT.COMPLETE"],
+	   "url":"https://example.com/webhooks/yellowcard"
+	}`, string(reqBody))
+
+	httpClient.MockRequest(uri+"/"+webhookID, func() (status int, body string) {
+		return http.StatusOK, `{}`
+	})
+
+	err = client.RemoveWebhook(ctx, webhookID)
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodDelete, httpClient.requests[3].Method)
+
+	// Test removing an unknown webhook
+	err = client.RemoveWebhook(ctx, "unknown")
+	assert.Error(t, err)
+}
+
+func TestNewClient_WithOpts(t *testing.T) {
+	client, err := New("key", "secret")
+	assert.NoError(t, err)
+	assert.NotNil(t, client)
+
+	creds, err := client.credentials(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, Credentials{Key: "key", Secret: "secret"}, creds)
+
+	client, err = New("key", "secret", WithEnvironment(EnvironmentSandbox))
+	assert.NoError(t, err)
+	assert.Equal(t, EnvironmentSandbox, client.config.env)
+	assert.Equal(t, _sandboxBaseURL, client.config.baseURL)
+
+	client, err = New("key", "secret", WithEnvironment(EnvironmentProduction))
+	assert.NoError(t, err)
+	assert.Equal(t, EnvironmentProduction, client.config.env)
+	assert.Equal(t, _prodBaseURL, client.config.baseURL)
+
+	// Test unknown environment
+	client, err = New("key", "secret", WithEnvironment(Environment(3)))
+	assert.ErrorIs(t, err, ErrInvalidEnvironment)
+	assert.Nil(t, client)
+
+	withHttpClient := &http.Client{
+		Timeout: 10 * time.Second,
+	}
+
+	client, err = New("key", "secret", WithHttpClient(withHttpClient))
+	assert.NoError(t, err)
+	assert.Equal(t, withHttpClient, client.config.httpClient)
+}
+
+func TestNewClient_WithBaseURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		want    string
+		wantErr error
+	}{
+		{name: "local stand-in", baseURL: "http://localhost:8080", want: "http://localhost:8080"},
+		{name: "proxy with path", baseURL: "https://proxy.internal/yellowcard/", want: "https://proxy.internal/yellowcard"},
+		{name: "missing scheme", baseURL: "api.yellowcard.io", wantErr: ErrInvalidBaseURL},
+		{name: "unsupported scheme", baseURL: "ftp://api.yellowcard.io", wantErr: ErrInvalidBaseURL},
+		{name: "empty", baseURL: "", wantErr: ErrInvalidBaseURL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := New("key", "secret", WithEnvironment(EnvironmentSandbox), WithBaseURL(tt.baseURL))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, client.config.baseURL)
+		})
+	}
+
+	// Test the base URL is kept whatever the order of the options
+	client, err := New("key", "secret", WithBaseURL("http://localhost:1"), WithEnvironment(EnvironmentSandbox))
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:1", client.config.baseURL)
+	assert.Equal(t, EnvironmentSandbox, client.config.env)
+
+	httpClient := newMockHttpClient()
+	client = newTestClient(t, WithHttpClient(httpClient), WithBaseURL("http://localhost:8080/"))
+
+	httpClient.MockRequest("http://localhost:8080/business/account", func() (int, string) {
+		return http.StatusOK, `{"id":"deb55c03-9961-417a-9550-f5ba7fe258e9"}`
+	})
+
+	_, err = client.GetAccount(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestNewClient_Validation(t *testing.T) {
+	_, err := New("", "secret")
+	assert.ErrorIs(t, err, ErrKeyRequired)
+
+	_, err = New("key", " ")
+	assert.ErrorIs(t, err, ErrSecretRequired)
 
-	client = New("key", "secret", WithHttpClient(withHttpClient))
-	assert.NotNil(t, client.config.httpClient)
-	assert.NotNil(t, client.config.httpClient)
+	_, err = New("", "", WithEnvironment(Environment(0)), WithBaseURL("localhost"))
+	assert.ErrorIs(t, err, ErrKeyRequired)
+	assert.ErrorIs(t, err, ErrSecretRequired)
+	assert.ErrorIs(t, err, ErrInvalidEnvironment)
+	assert.ErrorIs(t, err, ErrInvalidBaseURL)
 }
@@ -0,0 +1,102 @@
+package yellowcard
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// _skewResolution is the precision of the http Date header. Offsets below it cannot be measured.
+const _skewResolution = time.Second
+
+// Clock provides the current time used to sign requests.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc is a function implementing Clock.
+type ClockFunc func() time.Time
+
+// Now implements Clock.
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// WithClock configures the ClientConfig to sign requests using the time provided by the clock instead of the
+// system time.
+func WithClock(clock Clock) func(config *ClientConfig) {
+	return func(config *ClientConfig) {
+		if clock != nil {
+			config.clock = clock
+		}
+	}
+}
+
+// WithClockSkewCorrection configures the ClientConfig to estimate the offset between the clock and the API
+// servers from the Date header of responses, and to correct the X-YC-Timestamp of later requests by it.
+// This keeps requests from being rejected when the host clock drifts.
+func WithClockSkewCorrection() func(config *ClientConfig) {
+	return func(config *ClientConfig) {
+		config.skew = &skewEstimator{}
+	}
+}
+
+// ClockSkew returns the estimated offset between the API servers and the Client's clock, which is added to the
+// time used to sign requests. It is zero unless WithClockSkewCorrection is set.
+func (cl *Client) ClockSkew() time.Duration {
+	return cl.config.skew.offset()
+}
+
+// now returns the time used to sign a request, corrected by the estimated clock skew.
+func (cl *Client) now() time.Time {
+	return cl.config.clock.Now().Add(cl.config.skew.offset()).UTC()
+}
+
+// skewEstimator estimates the offset between the local clock and the server clock.
+type skewEstimator struct {
+	mu      sync.Mutex
+	current time.Duration
+	samples int
+}
+
+// offset returns the estimated offset. It is safe to call on a nil estimator.
+func (e *skewEstimator) offset() time.Duration {
+	if e == nil {
+		return 0
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.current
+}
+
+// observe updates the estimate using the Date header of a response received at the local time.
+func (e *skewEstimator) observe(resp *http.Response, received time.Time) {
+	if e == nil || resp == nil {
+		return
+	}
+
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return
+	}
+
+	// The Date header is truncated to the second, so the server time is on average half a second later.
+	sample := date.Add(_skewResolution / 2).Sub(received)
+	if sample.Abs() < _skewResolution {
+		sample = 0
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	// Average the samples exponentially so that a single outlier, e.g. from a caching proxy, has a limited effect.
+	if e.samples == 0 {
+		e.current = sample
+	} else {
+		e.current += (sample - e.current) / 2
+	}
+
+	e.samples++
+}
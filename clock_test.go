@@ -0,0 +1,94 @@
+package yellowcard
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_WithClock(t *testing.T) {
+	var (
+		fixedTime  = time.Date(2024, time.June, 14, 16, 20, 0, 0, time.FixedZone("EAT", 3*60*60))
+		httpClient = newMockHttpClient()
+		client     = newTestClient(t, WithHttpClient(httpClient), WithClock(ClockFunc(func() time.Time {
+			return fixedTime
+		})))
+	)
+
+	httpClient.MockRequest(client.config.baseURL+"/business/account", func() (int, string) {
+		return http.StatusOK, `{"id":"deb55c03-9961-417a-9550-f5ba7fe258e9"}`
+	})
+
+	for range 2 {
+		_, err := client.GetAccount(context.Background())
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, "2024-06-14T13:20:00Z", httpClient.requests[0].Header.Get("X-YC-Timestamp"))
+	assert.Equal(t,
+		httpClient.requests[0].Header.Get("Authorization"),
+		httpClient.requests[1].Header.Get("Authorization"),
+	)
+	assert.Zero(t, client.ClockSkew())
+}
+
+func TestClient_ClockSkewCorrection(t *testing.T) {
+	var (
+		localTime  = time.Date(2024, time.June, 14, 16, 20, 0, 0, time.UTC)
+		serverTime = localTime.Add(5 * time.Minute)
+		httpClient = newMockHttpClient()
+		client     = newTestClient(t,
+			WithHttpClient(httpClient),
+			WithClock(ClockFunc(func() time.Time { return localTime })),
+			WithClockSkewCorrection(),
+		)
+		header = http.Header{"Date": []string{serverTime.Format(http.TimeFormat)}}
+	)
+
+	httpClient.MockRequestWithHeader(client.config.baseURL+"/business/account", header, func() (int, string) {
+		return http.StatusUnauthorized, `{"code":"InvalidTimestamp","message":"timestamp is too old"}`
+	})
+
+	_, err := client.GetAccount(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, "2024-06-14T16:20:00Z", httpClient.requests[0].Header.Get("X-YC-Timestamp"))
+	assert.Equal(t, 5*time.Minute+500*time.Millisecond, client.ClockSkew())
+
+	_, _ = client.GetAccount(context.Background())
+	assert.Equal(t, "2024-06-14T16:25:00Z", httpClient.requests[1].Header.Get("X-YC-Timestamp"))
+}
+
+func TestSkewEstimator(t *testing.T) {
+	var (
+		received = time.Date(2024, time.June, 14, 16, 20, 0, 0, time.UTC)
+		response = func(date string) *http.Response {
+			return &http.Response{Header: http.Header{"Date": []string{date}}}
+		}
+	)
+
+	var nilEstimator *skewEstimator
+	nilEstimator.observe(response(received.Format(http.TimeFormat)), received)
+	assert.Zero(t, nilEstimator.offset())
+
+	e := &skewEstimator{}
+
+	// Offsets within the resolution of the Date header are ignored
+	e.observe(response(received.Format(http.TimeFormat)), received.Add(200*time.Millisecond))
+	assert.Zero(t, e.offset())
+
+	e = &skewEstimator{}
+
+	// Invalid dates are ignored
+	e.observe(response("yesterday"), received)
+	e.observe(&http.Response{}, received)
+	assert.Zero(t, e.offset())
+
+	e.observe(response(received.Add(-10*time.Second).Format(http.TimeFormat)), received)
+	assert.Equal(t, -9500*time.Millisecond, e.offset())
+
+	// Later samples are averaged with the estimate
+	e.observe(response(received.Add(-20*time.Second).Format(http.TimeFormat)), received)
+	assert.Equal(t, -14500*time.Millisecond, e.offset())
+}
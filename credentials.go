@@ -0,0 +1,199 @@
+package yellowcard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultKeyEnvVar is the environment variable holding the API key read by NewEnvCredentials.
+	DefaultKeyEnvVar = "YELLOWCARD_API_KEY"
+
+	// DefaultSecretEnvVar is the environment variable holding the API secret read by NewEnvCredentials.
+	DefaultSecretEnvVar = "YELLOWCARD_SECRET_KEY"
+)
+
+// ErrCredentialsUnavailable is returned when the credentials needed to sign a request or verify a webhook
+// cannot be retrieved from the CredentialsProvider. Requests failing with it are never sent.
+var ErrCredentialsUnavailable = errors.New("yellowcard: credentials unavailable")
+
+// ErrCredentialsNotFound is returned by a CredentialsProvider when the key or secret is not set.
+var ErrCredentialsNotFound = errors.New("yellowcard: credentials not found")
+
+// Credentials are the API key and secret used to sign requests.
+type Credentials struct {
+	Key    string `json:"key"`
+	Secret string `json:"secret"`
+}
+
+// valid reports whether both the key and secret are set.
+func (c Credentials) valid() bool {
+	return strings.TrimSpace(c.Key) != "" && strings.TrimSpace(c.Secret) != ""
+}
+
+// CredentialsProvider provides the credentials of the Client. It is consulted for every request so that keys
+// can be rotated without creating a new Client, and must be safe for concurrent use.
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (Credentials, error)
+}
+
+// CredentialsProviderFunc is a function implementing CredentialsProvider.
+type CredentialsProviderFunc func(ctx context.Context) (Credentials, error)
+
+// Credentials implements CredentialsProvider.
+func (f CredentialsProviderFunc) Credentials(ctx context.Context) (Credentials, error) {
+	return f(ctx)
+}
+
+// WithCredentialsProvider configures the ClientConfig to retrieve credentials from the provider instead of using
+// the key and secret passed to New, which may then be empty.
+func WithCredentialsProvider(provider CredentialsProvider) func(config *ClientConfig) {
+	return func(config *ClientConfig) {
+		config.credentials = provider
+	}
+}
+
+// NewStaticCredentials returns a CredentialsProvider that always provides the key and secret.
+func NewStaticCredentials(key string, secret string) CredentialsProvider {
+	creds := Credentials{Key: key, Secret: secret}
+
+	return CredentialsProviderFunc(func(context.Context) (Credentials, error) {
+		return creds, nil
+	})
+}
+
+// NewEnvCredentials returns a CredentialsProvider reading the key and secret from the environment variables on
+// every call, DefaultKeyEnvVar and DefaultSecretEnvVar when empty. ErrCredentialsNotFound is returned if either
+// variable is unset.
+func NewEnvCredentials(keyVar string, secretVar string) CredentialsProvider {
+	if keyVar == "" {
+		keyVar = DefaultKeyEnvVar
+	}
+
+	if secretVar == "" {
+		secretVar = DefaultSecretEnvVar
+	}
+
+	return CredentialsProviderFunc(func(context.Context) (Credentials, error) {
+		creds := Credentials{Key: os.Getenv(keyVar), Secret: os.Getenv(secretVar)}
+		if !creds.valid() {
+			return Credentials{}, fmt.Errorf("%w: %s and %s must be set", ErrCredentialsNotFound, keyVar, secretVar)
+		}
+
+		return creds, nil
+	})
+}
+
+// FileCredentials is a CredentialsProvider reading the key and secret from a JSON file such as
+// {"key": "...", "secret": "..."}. The file is read again whenever its size or modification time changes, so it
+// should be replaced atomically, e.g. by renaming a new file over it, when the key is rotated.
+type FileCredentials struct {
+	path string
+
+	mu      sync.Mutex
+	creds   Credentials
+	modTime time.Time
+	size    int64
+}
+
+// NewFileCredentials creates a FileCredentials reading the file at path.
+func NewFileCredentials(path string) *FileCredentials {
+	return &FileCredentials{path: path}
+}
+
+// Credentials implements CredentialsProvider.
+func (f *FileCredentials) Credentials(context.Context) (Credentials, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("yellowcard: read credentials file - %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.creds.valid() && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.creds, nil
+	}
+
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("yellowcard: read credentials file - %w", err)
+	}
+
+	var creds Credentials
+	if err = json.Unmarshal(data, &creds); err != nil {
+		return Credentials{}, fmt.Errorf("yellowcard: deserialize credentials file - %v", err)
+	}
+
+	if !creds.valid() {
+		return Credentials{}, fmt.Errorf("%w: %s must set key and secret", ErrCredentialsNotFound, f.path)
+	}
+
+	f.creds, f.modTime, f.size = creds, info.ModTime(), info.Size()
+	return creds, nil
+}
+
+// CachedCredentials is a CredentialsProvider caching the credentials of another provider, e.g. one fetching
+// them from a secrets manager, for a fixed duration.
+type CachedCredentials struct {
+	now      func() time.Time
+	provider CredentialsProvider
+	ttl      time.Duration
+
+	mu        sync.Mutex
+	creds     Credentials
+	expiresAt time.Time
+}
+
+// NewCachedCredentials creates a CachedCredentials fetching credentials from the provider at most once per ttl.
+func NewCachedCredentials(provider CredentialsProvider, ttl time.Duration) *CachedCredentials {
+	return &CachedCredentials{now: time.Now, provider: provider, ttl: ttl}
+}
+
+// Credentials implements CredentialsProvider. Concurrent callers wait for a single refresh of expired credentials.
+func (c *CachedCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if now.Before(c.expiresAt) {
+		return c.creds, nil
+	}
+
+	creds, err := c.provider.Credentials(ctx)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	c.creds, c.expiresAt = creds, now.Add(c.ttl)
+	return creds, nil
+}
+
+// Expire discards the cached credentials so that the next call fetches them again, e.g. after the API rejected
+// a rotated key.
+func (c *CachedCredentials) Expire() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.expiresAt = time.Time{}
+}
+
+// credentials retrieves the credentials of the Client.
+func (cl *Client) credentials(ctx context.Context) (Credentials, error) {
+	creds, err := cl.config.credentials.Credentials(ctx)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("%w: %w", ErrCredentialsUnavailable, err)
+	}
+
+	if !creds.valid() {
+		return Credentials{}, fmt.Errorf("%w: %w", ErrCredentialsUnavailable, ErrCredentialsNotFound)
+	}
+
+	return creds, nil
+}
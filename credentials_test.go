@@ -0,0 +1,202 @@
+package yellowcard
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewEnvCredentials(t *testing.T) {
+	provider := NewEnvCredentials("", "")
+
+	t.Setenv(DefaultKeyEnvVar, "")
+	t.Setenv(DefaultSecretEnvVar, "")
+
+	_, err := provider.Credentials(context.Background())
+	assert.ErrorIs(t, err, ErrCredentialsNotFound)
+
+	t.Setenv(DefaultKeyEnvVar, "key")
+	t.Setenv(DefaultSecretEnvVar, "secret")
+
+	creds, err := provider.Credentials(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, Credentials{Key: "key", Secret: "secret"}, creds)
+
+	t.Setenv(DefaultKeyEnvVar, "rotated-key")
+
+	creds, err = provider.Credentials(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "rotated-key", creds.Key)
+}
+
+func TestFileCredentials(t *testing.T) {
+	var (
+		path     = filepath.Join(t.TempDir(), "credentials.json")
+		provider = NewFileCredentials(path)
+		modTime  = time.Date(2024, time.June, 14, 16, 20, 0, 0, time.UTC)
+		write    = func(t *testing.T, content string, modTime time.Time) {
+			t.Helper()
+
+			assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+			assert.NoError(t, os.Chtimes(path, modTime, modTime))
+		}
+	)
+
+	_, err := provider.Credentials(context.Background())
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	write(t, `{"key":"key","secret":"secret"}`, modTime)
+
+	creds, err := provider.Credentials(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, Credentials{Key: "key", Secret: "secret"}, creds)
+
+	write(t, `{"key":"new","secret":"secret"}`, modTime.Add(time.Minute))
+
+	creds, err = provider.Credentials(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, Credentials{Key: "new", Secret: "secret"}, creds)
+
+	write(t, `{"key":"new"}`, modTime.Add(2*time.Minute))
+
+	_, err = provider.Credentials(context.Background())
+	assert.ErrorIs(t, err, ErrCredentialsNotFound)
+
+	write(t, `not json`, modTime.Add(3*time.Minute))
+
+	_, err = provider.Credentials(context.Background())
+	assert.Error(t, err)
+}
+
+func TestCachedCredentials(t *testing.T) {
+	var (
+		calls    int
+		now      = time.Date(2024, time.June, 14, 16, 20, 0, 0, time.UTC)
+		provider = NewCachedCredentials(CredentialsProviderFunc(func(context.Context) (Credentials, error) {
+			calls++
+			if calls == 3 {
+				return Credentials{}, errors.New("secrets manager unavailable")
+			}
+
+			return Credentials{Key: "key", Secret: "secret"}, nil
+		}), time.Minute)
+	)
+
+	provider.now = func() time.Time { return now }
+
+	for range 3 {
+		_, err := provider.Credentials(context.Background())
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, 1, calls)
+
+	now = now.Add(time.Minute)
+
+	_, err := provider.Credentials(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	provider.Expire()
+
+	_, err = provider.Credentials(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, 3, calls)
+
+	_, err = provider.Credentials(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 4, calls)
+}
+
+func TestClient_CredentialsProvider(t *testing.T) {
+	var (
+		creds      = Credentials{Key: "key", Secret: "secret"}
+		fail       bool
+		httpClient = newMockHttpClient()
+		provider   = CredentialsProviderFunc(func(context.Context) (Credentials, error) {
+			if fail {
+				return Credentials{}, errors.New("secrets manager unavailable")
+			}
+
+			return creds, nil
+		})
+	)
+
+	client, err := New("", "",
+		WithHttpClient(httpClient),
+		WithCredentialsProvider(provider),
+		WithRetryPolicy(&RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}),
+	)
+	assert.NoError(t, err)
+
+	httpClient.MockRequest(client.config.baseURL+"/business/account", func() (int, string) {
+		return http.StatusOK, `{"id":"deb55c03-9961-417a-9550-f5ba7fe258e9"}`
+	})
+
+	_, err = client.GetAccount(context.Background())
+	assert.NoError(t, err)
+
+	creds = Credentials{Key: "rotated-key", Secret: "rotated-secret"}
+
+	_, err = client.GetAccount(context.Background())
+	assert.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(httpClient.requests[0].Header.Get("Authorization"), "YcHmacV1 key:"))
+	assert.True(t, strings.HasPrefix(httpClient.requests[1].Header.Get("Authorization"), "YcHmacV1 rotated-key:"))
+
+	verifier := NewVerifier(map[string]string{"rotated-key": "rotated-secret"})
+	_, err = verifier.Verify(httpClient.requests[1])
+	assert.NoError(t, err)
+
+	fail = true
+
+	_, err = client.GetAccount(context.Background())
+	assert.ErrorIs(t, err, ErrCredentialsUnavailable)
+	assert.Len(t, httpClient.requests, 2)
+}
+
+func TestWebhookHandler_CredentialsProvider(t *testing.T) {
+	var (
+		secret = "secret"
+		client = newTestClient(t, WithCredentialsProvider(CredentialsProviderFunc(
+			func(context.Context) (Credentials, error) {
+				if secret == "" {
+					return Credentials{}, ErrCredentialsNotFound
+				}
+
+				return Credentials{Key: "key", Secret: secret}, nil
+			},
+		)))
+		now     = time.Date(2024, time.June, 15, 7, 10, 0, 0, time.UTC)
+		payload = `{"id":"d83011e8-341f-5e3e-b908-84cb4a552fcc","event":"PAYMENT.COMPLETE","executedAt":1718435340000}`
+		handler = client.WebhookHandler(func(ctx context.Context, event *WebhookEvent) error {
+			return nil
+		})
+		deliver = func(signature string) int {
+			req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(payload))
+			req.Header.Set(WebhookSignatureHeader, signature)
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			return rec.Code
+		}
+	)
+
+	handler.now = func() time.Time { return now }
+
+	assert.Equal(t, http.StatusOK, deliver(signWebhookPayload("secret", payload)))
+
+	secret = "rotated-secret"
+	assert.Equal(t, http.StatusUnauthorized, deliver(signWebhookPayload("secret", payload)))
+	assert.Equal(t, http.StatusOK, deliver(signWebhookPayload("rotated-secret", payload)))
+
+	secret = ""
+	assert.Equal(t, http.StatusInternalServerError, deliver(signWebhookPayload("rotated-secret", payload)))
+}
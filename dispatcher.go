@@ -0,0 +1,149 @@
+package yellowcard
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// EventHandler handles a single webhook event.
+type EventHandler func(ctx context.Context, event *WebhookEvent) error
+
+// EventMiddleware wraps an EventHandler to run logic before or after it, such as logging or recovery.
+type EventMiddleware func(next EventHandler) EventHandler
+
+// Dispatcher routes webhook events to the handlers registered for their EventType.
+// Dispatcher.Dispatch satisfies WebhookHandlerFunc and can be passed straight to Client.WebhookHandler.
+type Dispatcher struct {
+	mu         sync.RWMutex
+	handlers   map[EventType][]EventHandler
+	middleware []EventMiddleware
+	fallback   EventHandler
+}
+
+// NewDispatcher creates a Dispatcher with no registered handlers.
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{
+		handlers: make(map[EventType][]EventHandler),
+	}
+}
+
+// Use appends middleware that wraps every dispatched event. Middleware runs in the order it was added.
+func (d *Dispatcher) Use(middleware ...EventMiddleware) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.middleware = append(d.middleware, middleware...)
+}
+
+// On registers a handler for the given event. Handlers for the same event run in the order they were
+// registered and dispatching stops at the first error.
+func (d *Dispatcher) On(event EventType, handler EventHandler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	key := EventType(event.normalize())
+	d.handlers[key] = append(d.handlers[key], handler)
+}
+
+// OnUnhandled registers a handler for events that have no handler registered.
+// By default, such events are acknowledged and dropped.
+func (d *Dispatcher) OnUnhandled(handler EventHandler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.fallback = handler
+}
+
+// OnPayment registers a handler that receives the Payment of the given payment event.
+func (d *Dispatcher) OnPayment(event EventType, fn func(ctx context.Context, payment *Payment) error) {
+	d.On(event, func(ctx context.Context, e *WebhookEvent) error {
+		if e.Payment == nil {
+			return fmt.Errorf("yellowcard: %s event has no payment", e.Event)
+		}
+
+		return fn(ctx, e.Payment)
+	})
+}
+
+// OnCollection registers a handler that receives the Collection of the given collection event.
+func (d *Dispatcher) OnCollection(event EventType, fn func(ctx context.Context, collection *Collection) error) {
+	d.On(event, func(ctx context.Context, e *WebhookEvent) error {
+		if e.Collection == nil {
+			return fmt.Errorf("yellowcard: %s event has no collection", e.Event)
+		}
+
+		return fn(ctx, e.Collection)
+	})
+}
+
+// OnPaymentPending registers a handler for EventPaymentPending.
+func (d *Dispatcher) OnPaymentPending(fn func(ctx context.Context, payment *Payment) error) {
+	d.OnPayment(EventPaymentPending, fn)
+}
+
+// OnPaymentProcessing registers a handler for EventPaymentProcessing.
+func (d *Dispatcher) OnPaymentProcessing(fn func(ctx context.Context, payment *Payment) error) {
+	d.OnPayment(EventPaymentProcessing, fn)
+}
+
+// OnPaymentComplete registers a handler for EventPaymentComplete.
+func (d *Dispatcher) OnPaymentComplete(fn func(ctx context.Context, payment *Payment) error) {
+	d.OnPayment(EventPaymentComplete, fn)
+}
+
+// OnPaymentFailed registers a handler for EventPaymentFailed.
+func (d *Dispatcher) OnPaymentFailed(fn func(ctx context.Context, payment *Payment) error) {
+	d.OnPayment(EventPaymentFailed, fn)
+}
+
+// OnCollectionPending registers a handler for EventCollectionPending.
+func (d *Dispatcher) OnCollectionPending(fn func(ctx context.Context, collection *Collection) error) {
+	d.OnCollection(EventCollectionPending, fn)
+}
+
+// OnCollectionProcessing registers a handler for EventCollectionProcessing.
+func (d *Dispatcher) OnCollectionProcessing(fn func(ctx context.Context, collection *Collection) error) {
+	d.OnCollection(EventCollectionProcessing, fn)
+}
+
+// OnCollectionComplete registers a handler for EventCollectionComplete.
+func (d *Dispatcher) OnCollectionComplete(fn func(ctx context.Context, collection *Collection) error) {
+	d.OnCollection(EventCollectionComplete, fn)
+}
+
+// OnCollectionFailed registers a handler for EventCollectionFailed.
+func (d *Dispatcher) OnCollectionFailed(fn func(ctx context.Context, collection *Collection) error) {
+	d.OnCollection(EventCollectionFailed, fn)
+}
+
+// Dispatch passes the event through the middleware chain to the handlers registered for it.
+func (d *Dispatcher) Dispatch(ctx context.Context, event *WebhookEvent) error {
+	d.mu.RLock()
+	var (
+		handlers   = d.handlers[EventType(event.Event.normalize())]
+		middleware = d.middleware
+		fallback   = d.fallback
+	)
+	d.mu.RUnlock()
+
+	var handler EventHandler = func(ctx context.Context, event *WebhookEvent) error {
+		if len(handlers) == 0 && fallback != nil {
+			return fallback(ctx, event)
+		}
+
+		for _, h := range handlers {
+			if err := h(ctx, event); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+
+	return handler(ctx, event)
+}
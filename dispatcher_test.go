@@ -0,0 +1,100 @@
+package yellowcard
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDispatcher_Dispatch(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		dispatcher = NewDispatcher()
+		calls      []string
+	)
+
+	dispatcher.Use(func(next EventHandler) EventHandler {
+		return func(ctx context.Context, event *WebhookEvent) error {
+			calls = append(calls, "middleware:"+string(event.Event))
+			return next(ctx, event)
+		}
+	})
+
+	dispatcher.OnPaymentComplete(func(ctx context.Context, payment *Payment) error {
+		calls = append(calls, "payment:"+payment.ID)
+		return nil
+	})
+
+	dispatcher.OnCollectionFailed(func(ctx context.Context, collection *Collection) error {
+		calls = append(calls, "collection:"+collection.ID)
+		return errors.New("collection handler failed")
+	})
+
+	err := dispatcher.Dispatch(ctx, &WebhookEvent{
+		Event:   "payment.complete",
+		Payment: &Payment{ID: "d83011e8-341f-5e3e-b908-84cb4a552fcc"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"middleware:payment.complete", "payment:d83011e8-341f-5e3e-b908-84cb4a552fcc"}, calls)
+
+	// Test handler errors are returned
+	calls = nil
+	err = dispatcher.Dispatch(ctx, &WebhookEvent{
+		Event:      EventCollectionFailed,
+		Collection: &Collection{ID: "4f8a0b4e-3c6e-5f7a-9d2b-1e0c6a7b8d9f"},
+	})
+
+	assert.EqualError(t, err, "collection handler failed")
+	assert.Len(t, calls, 2)
+
+	// Test events without a decoded payment are rejected
+	err = dispatcher.Dispatch(ctx, &WebhookEvent{Event: EventPaymentComplete})
+	assert.Error(t, err)
+
+	// Test unhandled events are acknowledged unless a fallback is registered
+	calls = nil
+	err = dispatcher.Dispatch(ctx, &WebhookEvent{Event: EventPaymentPending})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"middleware:PAYMENT.PENDING"}, calls)
+
+	dispatcher.OnUnhandled(func(ctx context.Context, event *WebhookEvent) error {
+		calls = append(calls, "unhandled:"+string(event.Event))
+		return nil
+	})
+
+	calls = nil
+	err = dispatcher.Dispatch(ctx, &WebhookEvent{Event: EventPaymentPending})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"middleware:PAYMENT.PENDING", "unhandled:PAYMENT.PENDING"}, calls)
+}
+
+func TestDispatcher_WithWebhookHandler(t *testing.T) {
+	var (
+		client     = newTestClient(t)
+		dispatcher = NewDispatcher()
+		payload    = `{"id":"d83011e8-341f-5e3e-b908-84cb4a552fcc","status":"failed","event":"PAYMENT.FAILED"}`
+		failed     *Payment
+	)
+
+	dispatcher.OnPaymentFailed(func(ctx context.Context, payment *Payment) error {
+		failed = payment
+		return nil
+	})
+
+	handler := client.WebhookHandler(dispatcher.Dispatch, WithWebhookTolerance(0))
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(payload))
+	req.Header.Set(WebhookSignatureHeader, signWebhookPayload("secret", payload))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotNil(t, failed)
+	assert.Equal(t, "failed", failed.Status)
+}
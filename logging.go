@@ -0,0 +1,202 @@
+package yellowcard
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+)
+
+const _redacted = "[REDACTED]"
+
+// _piiFields are the JSON fields holding personal information that are redacted from logged bodies.
+var _piiFields = map[string]struct{}{
+	"accountName":   {},
+	"accountNumber": {},
+	"address":       {},
+	"dob":           {},
+	"email":         {},
+	"idNumber":      {},
+	"name":          {},
+	"phone":         {},
+}
+
+// WithLogger configures the ClientConfig to log every request attempt with its operation, method, path,
+// status, latency and attempt number.
+func WithLogger(logger *slog.Logger) func(config *ClientConfig) {
+	return func(config *ClientConfig) {
+		config.logger = logger
+	}
+}
+
+// WithBodyLogging configures the ClientConfig to include request and response bodies when logging requests.
+// Personal information such as names, phone numbers, emails, ID numbers, dates of birth and account numbers
+// is redacted. It has no effect unless a logger is set using WithLogger.
+func WithBodyLogging() func(config *ClientConfig) {
+	return func(config *ClientConfig) {
+		config.logBodies = true
+	}
+}
+
+// logAttempt logs a completed attempt. Successful attempts are logged at slog.LevelInfo and failed ones at
+// slog.LevelWarn.
+func (cl *Client) logAttempt(ctx context.Context, a *attempt) {
+	logger := cl.config.logger
+	if logger == nil {
+		return
+	}
+
+	level := slog.LevelInfo
+	if a.err != nil {
+		level = slog.LevelWarn
+	}
+
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+
+	var status int
+	if a.response != nil {
+		status = a.response.StatusCode
+	}
+
+	attrs := []slog.Attr{
+		slog.String("operation", a.op.String()),
+		slog.String("method", a.method),
+		slog.String("path", a.path),
+		slog.Int("status", status),
+		slog.Duration("latency", a.latency),
+		slog.Int("attempt", a.number),
+	}
+
+	if a.err != nil {
+		attrs = append(attrs, slog.String("error", a.err.Error()))
+	}
+
+	if cl.config.logBodies {
+		if len(a.reqBody) != 0 {
+			attrs = append(attrs, slog.String("request_body", redactJSON(a.reqBody)))
+		}
+
+		if len(a.resBody) != 0 {
+			attrs = append(attrs, slog.String("response_body", redactJSON(a.resBody)))
+		}
+	}
+
+	logger.LogAttrs(ctx, level, "yellowcard: request", attrs...)
+}
+
+// redactJSON returns the JSON document with the values of personal information fields redacted.
+// Documents that cannot be parsed are omitted entirely as they cannot be redacted.
+func redactJSON(data []byte) string {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return "[OMITTED: invalid JSON]"
+	}
+
+	redacted, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return "[OMITTED: invalid JSON]"
+	}
+
+	return string(redacted)
+}
+
+// redactValue replaces the values of personal information fields found in v.
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if _, ok := _piiFields[key]; ok && value != nil && value != "" {
+				v[key] = _redacted
+				continue
+			}
+
+			v[key] = redactValue(value)
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = redactValue(value)
+		}
+	}
+
+	return v
+}
+
+// LogValue implements slog.LogValuer, omitting the sender's personal information.
+func (s Sender) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("country", s.Country),
+		slog.String("idType", s.IDType),
+		slog.String("name", redactString(s.Name)),
+	)
+}
+
+// LogValue implements slog.LogValuer, omitting the recipient's personal information.
+func (r Recipient) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("country", r.Country),
+		slog.String("idType", r.IDType),
+		slog.String("name", redactString(r.Name)),
+	)
+}
+
+// LogValue implements slog.LogValuer, omitting the account holder's details.
+func (d Destination) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("accountNumber", redactString(d.AccountNumber)),
+		slog.String("accountType", string(d.AccountType)),
+		slog.String("country", d.Country),
+		slog.String("networkId", d.NetworkID),
+		slog.String("networkName", d.NetworkName),
+	)
+}
+
+// LogValue implements slog.LogValuer, omitting the account number.
+func (s Source) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("accountNumber", redactString(s.AccountNumber)),
+		slog.String("accountType", string(s.AccountType)),
+		slog.String("networkId", s.NetworkID),
+	)
+}
+
+// LogValue implements slog.LogValuer, omitting the personal information of the sender and destination.
+func (p Payment) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", p.ID),
+		slog.String("sequenceId", p.SequenceID),
+		slog.String("status", p.Status),
+		slog.Float64("amount", p.Amount),
+		slog.Float64("convertedAmount", p.ConvertedAmount),
+		slog.String("currency", p.Currency),
+		slog.String("country", p.Country),
+		slog.String("channelId", p.ChannelID),
+		slog.Any("sender", p.Sender),
+		slog.Any("destination", p.Destination),
+	)
+}
+
+// LogValue implements slog.LogValuer, omitting the personal information of the recipient and source.
+func (c Collection) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", c.ID),
+		slog.String("sequenceId", c.SequenceID),
+		slog.String("status", c.Status),
+		slog.Float64("amount", c.Amount),
+		slog.Float64("convertedAmount", c.ConvertedAmount),
+		slog.String("currency", c.Currency),
+		slog.String("country", c.Country),
+		slog.String("channelId", c.ChannelID),
+		slog.Any("recipient", c.Recipient),
+		slog.Any("source", c.Source),
+	)
+}
+
+// redactString returns _redacted for non-empty values so that logs show whether a field was set.
+func redactString(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	return _redacted
+}
@@ -0,0 +1,134 @@
+package yellowcard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_Logger(t *testing.T) {
+	var (
+		buf        bytes.Buffer
+		httpClient = newMockHttpClient()
+		client     = newTestClient(t,
+			WithHttpClient(httpClient),
+			WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))),
+			WithRetryPolicy(&RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}),
+		)
+		statuses = []int{http.StatusServiceUnavailable, http.StatusOK}
+	)
+
+	httpClient.MockRequest(client.config.baseURL+"/business/account", func() (int, string) {
+		status := statuses[0]
+		statuses = statuses[1:]
+		return status, `{"id":"deb55c03-9961-417a-9550-f5ba7fe258e9"}`
+	})
+
+	_, err := client.GetAccount(context.Background())
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+
+	var first, second map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &second))
+
+	assert.Equal(t, "WARN", first["level"])
+	assert.Equal(t, "GetAccount", first["operation"])
+	assert.Equal(t, http.MethodGet, first["method"])
+	assert.Equal(t, "/business/account", first["path"])
+	assert.EqualValues(t, http.StatusServiceUnavailable, first["status"])
+	assert.EqualValues(t, 1, first["attempt"])
+	assert.Contains(t, first, "latency")
+	assert.Contains(t, first, "error")
+	assert.NotContains(t, first, "response_body")
+
+	assert.Equal(t, "INFO", second["level"])
+	assert.EqualValues(t, http.StatusOK, second["status"])
+	assert.EqualValues(t, 2, second["attempt"])
+	assert.NotContains(t, second, "error")
+}
+
+func TestClient_LoggerBodies(t *testing.T) {
+	var (
+		buf        bytes.Buffer
+		httpClient = newMockHttpClient()
+		client     = newTestClient(t,
+			WithHttpClient(httpClient),
+			WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))),
+			WithBodyLogging(),
+		)
+	)
+
+	httpClient.MockRequest(client.config.baseURL+"/business/payments", func() (int, string) {
+		return http.StatusOK, `
+		{
+		   "id":"0aa5bd35-b969-5d1d-ae7b-dfc0c4abbaf7",
+		   "destination":{"accountName":"Ken Adams","accountNumber":"+12222222222","accountType":"momo"},
+		   "sender":{"name":"Sample Name","phone":"+12222222222","email":"email@domain.com","country":"US"},
+		   "sequenceId":"nsahHJODjx",
+		   "status":"created"
+		}`
+	})
+
+	_, err := client.MakePayment(context.Background(), &PaymentRequest{
+		Amount:    100,
+		ChannelID: "81018280-e320-4c81-9b2f-6f636c2239d8",
+		Destination: Destination{
+			AccountName:   "Ken Adams",
+			AccountNumber: "+12222222222",
+			AccountType:   AccountTypeMobileMoney,
+		},
+		Sender: Sender{
+			Country:  "US",
+			Dob:      "10/10/1950",
+			Email:    "email@domain.com",
+			IDNumber: "0123456789",
+			Name:     "Sample Name",
+			Phone:    "+12222222222",
+		},
+		SequenceID: "nsahHJODjx",
+	}, false)
+	assert.NoError(t, err)
+
+	out := buf.String()
+	for _, pii := range []string{"Ken Adams", "+12222222222", "email@domain.com", "0123456789", "10/10/1950", "Sample Name"} {
+		assert.NotContains(t, out, pii)
+	}
+
+	var entry map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Contains(t, entry["request_body"], `"sequenceId":"nsahHJODjx"`)
+	assert.Contains(t, entry["request_body"], `"country":"US"`)
+	assert.Contains(t, entry["response_body"], `"id":"0aa5bd35-b969-5d1d-ae7b-dfc0c4abbaf7"`)
+	assert.Contains(t, entry["response_body"], `"name":"[REDACTED]"`)
+}
+
+func TestPayment_LogValue(t *testing.T) {
+	var buf bytes.Buffer
+
+	payment := &Payment{
+		Destination: Destination{AccountName: "Ken Adams", AccountNumber: "+12222222222", Country: "ZA"},
+		ID:          "0aa5bd35-b969-5d1d-ae7b-dfc0c4abbaf7",
+		Sender:      Sender{Country: "US", Email: "email@domain.com", Name: "Sample Name", Phone: "+15555555555"},
+		SequenceID:  "nsahHJODjx",
+	}
+
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("payment", "payment", payment)
+
+	out := buf.String()
+	assert.Contains(t, out, "payment.id=0aa5bd35-b969-5d1d-ae7b-dfc0c4abbaf7")
+	assert.Contains(t, out, "payment.sender.name=[REDACTED]")
+	assert.Contains(t, out, "payment.destination.country=ZA")
+
+	for _, pii := range []string{"Ken Adams", "+12222222222", "email@domain.com", "Sample Name", "+15555555555"} {
+		assert.NotContains(t, out, pii)
+	}
+}
@@ -0,0 +1,101 @@
+package yellowcard
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// Error codes reported to Metrics for requests that failed without an error response.
+const (
+	ErrorCodeCanceled         = "Canceled"
+	ErrorCodeCircuitOpen      = "CircuitOpen"
+	ErrorCodeCredentials      = "CredentialsUnavailable"
+	ErrorCodeDeadlineExceeded = "DeadlineExceeded"
+	ErrorCodeRateLimited      = "RateLimited"
+	ErrorCodeRequestFailed    = "RequestFailed"
+)
+
+// Metrics collects metrics about the requests made by the Client. Implementations must be safe for concurrent
+// use. See PrometheusMetrics and ExpvarMetrics.
+type Metrics interface {
+	// RequestStarted is called before every request is sent.
+	RequestStarted(op Operation)
+	// RequestFinished is called once a request started with RequestStarted has completed.
+	RequestFinished(op Operation, result RequestResult)
+	// RequestRetried is called before a failed request is retried.
+	RequestRetried(op Operation)
+}
+
+// RequestResult is the outcome of a request reported to Metrics.
+type RequestResult struct {
+	// ErrorCode is the code of the error response, or one of the ErrorCode constants if the request failed
+	// without one. It is empty when the request succeeded.
+	ErrorCode string
+	Latency   time.Duration
+	// StatusCode is the status code of the response, or 0 when none was received.
+	StatusCode int
+}
+
+// WithMetrics configures the ClientConfig to report every request to the Metrics.
+func WithMetrics(metrics Metrics) func(config *ClientConfig) {
+	return func(config *ClientConfig) {
+		config.metrics = metrics
+	}
+}
+
+// requestStarted reports the start of a request to the configured Metrics.
+func (cl *Client) requestStarted(op Operation) {
+	if cl.config.metrics != nil {
+		cl.config.metrics.RequestStarted(op)
+	}
+}
+
+// requestRetried reports a retry to the configured Metrics.
+func (cl *Client) requestRetried(op Operation) {
+	if cl.config.metrics != nil {
+		cl.config.metrics.RequestRetried(op)
+	}
+}
+
+// recordAttempt reports a completed attempt to the configured Metrics.
+func (cl *Client) recordAttempt(a *attempt) {
+	if cl.config.metrics == nil {
+		return
+	}
+
+	result := RequestResult{Latency: a.latency}
+	if a.response != nil {
+		result.StatusCode = a.response.StatusCode
+	}
+
+	if a.err != nil {
+		result.ErrorCode = errorCode(a.err, result.StatusCode)
+	}
+
+	cl.config.metrics.RequestFinished(a.op, result)
+}
+
+// errorCode classifies err for Metrics, using the code of the error response when there is one.
+func errorCode(err error, status int) string {
+	var errResp *errorResponse
+	switch {
+	case errors.As(err, &errResp) && errResp.Code != "":
+		return errResp.Code
+	case status != 0:
+		return strconv.Itoa(status)
+	case errors.Is(err, ErrRateLimited):
+		return ErrorCodeRateLimited
+	case errors.Is(err, ErrCircuitOpen):
+		return ErrorCodeCircuitOpen
+	case errors.Is(err, ErrCredentialsUnavailable):
+		return ErrorCodeCredentials
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorCodeDeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		return ErrorCodeCanceled
+	default:
+		return ErrorCodeRequestFailed
+	}
+}
@@ -0,0 +1,85 @@
+package yellowcard
+
+import (
+	"expvar"
+	"strconv"
+	"sync"
+)
+
+// ExpvarMetrics is a Metrics implementation publishing its metrics using the expvar package, and so at
+// /debug/vars when the expvar handler is registered. Metrics are published per operation as a map holding the
+// number of requests by status code, errors by error code, retries, requests in flight and the total latency.
+type ExpvarMetrics struct {
+	mu         sync.Mutex
+	operations map[Operation]*expvarOperation
+	vars       *expvar.Map
+}
+
+type expvarOperation struct {
+	errors         *expvar.Map
+	inFlight       *expvar.Int
+	latencySeconds *expvar.Float
+	requests       *expvar.Map
+	retries        *expvar.Int
+}
+
+// NewExpvarMetrics creates an ExpvarMetrics published under the name. Like expvar.Publish, it panics if the
+// name is already in use.
+func NewExpvarMetrics(name string) *ExpvarMetrics {
+	return &ExpvarMetrics{
+		operations: make(map[Operation]*expvarOperation),
+		vars:       expvar.NewMap(name),
+	}
+}
+
+// operation returns the variables of the operation, publishing them on first use.
+func (m *ExpvarMetrics) operation(op Operation) *expvarOperation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if o, ok := m.operations[op]; ok {
+		return o
+	}
+
+	o := &expvarOperation{
+		errors:         new(expvar.Map).Init(),
+		inFlight:       new(expvar.Int),
+		latencySeconds: new(expvar.Float),
+		requests:       new(expvar.Map).Init(),
+		retries:        new(expvar.Int),
+	}
+
+	vars := new(expvar.Map).Init()
+	vars.Set("errors", o.errors)
+	vars.Set("in_flight", o.inFlight)
+	vars.Set("latency_seconds", o.latencySeconds)
+	vars.Set("requests", o.requests)
+	vars.Set("retries", o.retries)
+
+	m.operations[op] = o
+	m.vars.Set(op.String(), vars)
+	return o
+}
+
+// RequestStarted implements Metrics.
+func (m *ExpvarMetrics) RequestStarted(op Operation) {
+	m.operation(op).inFlight.Add(1)
+}
+
+// RequestFinished implements Metrics.
+func (m *ExpvarMetrics) RequestFinished(op Operation, result RequestResult) {
+	o := m.operation(op)
+
+	o.inFlight.Add(-1)
+	o.latencySeconds.Add(result.Latency.Seconds())
+	o.requests.Add(strconv.Itoa(result.StatusCode), 1)
+
+	if result.ErrorCode != "" {
+		o.errors.Add(result.ErrorCode, 1)
+	}
+}
+
+// RequestRetried implements Metrics.
+func (m *ExpvarMetrics) RequestRetried(op Operation) {
+	m.operation(op).retries.Add(1)
+}
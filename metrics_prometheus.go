@@ -0,0 +1,206 @@
+package yellowcard
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultLatencyBuckets are the upper bounds, in seconds, of the request latency histogram buckets.
+var DefaultLatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// PrometheusMetrics is a Metrics implementation exposing its metrics in the Prometheus text format.
+// It serves them over http so that it can be registered as a scrape endpoint, e.g. at /metrics.
+type PrometheusMetrics struct {
+	buckets   []float64
+	namespace string
+
+	mu        sync.Mutex
+	errors    map[[2]string]uint64
+	inFlight  map[Operation]int64
+	latencies map[Operation]*histogram
+	requests  map[[2]string]uint64
+	retries   map[Operation]uint64
+}
+
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// NewPrometheusMetrics creates a PrometheusMetrics prefixing metric names with the namespace, "yellowcard" if
+// empty, and recording latencies using the buckets, DefaultLatencyBuckets if none are given.
+func NewPrometheusMetrics(namespace string, buckets ...float64) *PrometheusMetrics {
+	if namespace == "" {
+		namespace = "yellowcard"
+	}
+
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+
+	buckets = slices.Clone(buckets)
+	slices.Sort(buckets)
+
+	return &PrometheusMetrics{
+		buckets:   buckets,
+		errors:    make(map[[2]string]uint64),
+		inFlight:  make(map[Operation]int64),
+		latencies: make(map[Operation]*histogram),
+		namespace: namespace,
+		requests:  make(map[[2]string]uint64),
+		retries:   make(map[Operation]uint64),
+	}
+}
+
+// RequestStarted implements Metrics.
+func (m *PrometheusMetrics) RequestStarted(op Operation) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.inFlight[op]++
+}
+
+// RequestFinished implements Metrics.
+func (m *PrometheusMetrics) RequestFinished(op Operation, result RequestResult) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.inFlight[op]--
+	m.requests[[2]string{op.String(), strconv.Itoa(result.StatusCode)}]++
+
+	if result.ErrorCode != "" {
+		m.errors[[2]string{op.String(), result.ErrorCode}]++
+	}
+
+	h, ok := m.latencies[op]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(m.buckets))}
+		m.latencies[op] = h
+	}
+
+	seconds := result.Latency.Seconds()
+	for i, upper := range m.buckets {
+		if seconds <= upper {
+			h.counts[i]++
+		}
+	}
+
+	h.count++
+	h.sum += seconds
+}
+
+// RequestRetried implements Metrics.
+func (m *PrometheusMetrics) RequestRetried(op Operation) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.retries[op]++
+}
+
+// ServeHTTP writes the metrics in the Prometheus text format.
+func (m *PrometheusMetrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = m.WriteTo(w)
+}
+
+// WriteTo writes the metrics in the Prometheus text format to w.
+func (m *PrometheusMetrics) WriteTo(w io.Writer) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+
+	name := m.namespace + "_requests_total"
+	cw.printf("# HELP %s Total number of requests made to the Yellow Card API.\n# TYPE %s counter\n", name, name)
+	for _, key := range sortedKeys(m.requests, compareLabels) {
+		cw.printf("%s{operation=%s,status=%s} %d\n", name, quoteLabel(key[0]), quoteLabel(key[1]), m.requests[key])
+	}
+
+	name = m.namespace + "_request_errors_total"
+	cw.printf("# HELP %s Total number of failed requests by error code.\n# TYPE %s counter\n", name, name)
+	for _, key := range sortedKeys(m.errors, compareLabels) {
+		cw.printf("%s{operation=%s,code=%s} %d\n", name, quoteLabel(key[0]), quoteLabel(key[1]), m.errors[key])
+	}
+
+	name = m.namespace + "_request_retries_total"
+	cw.printf("# HELP %s Total number of retried requests.\n# TYPE %s counter\n", name, name)
+	for _, op := range sortedKeys(m.retries, cmp.Compare[Operation]) {
+		cw.printf("%s{operation=%s} %d\n", name, quoteLabel(op.String()), m.retries[op])
+	}
+
+	name = m.namespace + "_requests_in_flight"
+	cw.printf("# HELP %s Number of requests currently in flight.\n# TYPE %s gauge\n", name, name)
+	for _, op := range sortedKeys(m.inFlight, cmp.Compare[Operation]) {
+		cw.printf("%s{operation=%s} %d\n", name, quoteLabel(op.String()), m.inFlight[op])
+	}
+
+	name = m.namespace + "_request_duration_seconds"
+	cw.printf("# HELP %s Latency of requests made to the Yellow Card API.\n# TYPE %s histogram\n", name, name)
+	for _, op := range sortedKeys(m.latencies, cmp.Compare[Operation]) {
+		h, label := m.latencies[op], quoteLabel(op.String())
+
+		for i, upper := range m.buckets {
+			le := strconv.FormatFloat(upper, 'g', -1, 64)
+			cw.printf("%s_bucket{operation=%s,le=%s} %d\n", name, label, quoteLabel(le), h.counts[i])
+		}
+
+		cw.printf("%s_bucket{operation=%s,le=\"+Inf\"} %d\n", name, label, h.count)
+		cw.printf("%s_sum{operation=%s} %s\n", name, label, strconv.FormatFloat(h.sum, 'g', -1, 64))
+		cw.printf("%s_count{operation=%s} %d\n", name, label, h.count)
+	}
+
+	if cw.err == nil {
+		cw.err = cw.w.Flush()
+	}
+
+	return cw.n, cw.err
+}
+
+// countingWriter writes formatted output, keeping track of the bytes written and the first error.
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (cw *countingWriter) printf(format string, args ...any) {
+	if cw.err != nil {
+		return
+	}
+
+	n, err := fmt.Fprintf(cw.w, format, args...)
+	cw.n += int64(n)
+	cw.err = err
+}
+
+// quoteLabel quotes a label value, escaping backslashes, double quotes and line feeds.
+func quoteLabel(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value) + `"`
+}
+
+// sortedKeys returns the keys of the map sorted using cmp so that the output is stable.
+func sortedKeys[K comparable, V any](m map[K]V, cmp func(a, b K) int) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	slices.SortFunc(keys, cmp)
+	return keys
+}
+
+func compareLabels(a, b [2]string) int {
+	if c := strings.Compare(a[0], b[0]); c != 0 {
+		return c
+	}
+
+	return strings.Compare(a[1], b[1])
+}
@@ -0,0 +1,170 @@
+package yellowcard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type recordingMetrics struct {
+	events  []string
+	results []RequestResult
+}
+
+func (m *recordingMetrics) RequestStarted(op Operation) {
+	m.events = append(m.events, "started:"+op.String())
+}
+
+func (m *recordingMetrics) RequestFinished(op Operation, result RequestResult) {
+	m.events = append(m.events, "finished:"+op.String())
+	m.results = append(m.results, result)
+}
+
+func (m *recordingMetrics) RequestRetried(op Operation) {
+	m.events = append(m.events, "retried:"+op.String())
+}
+
+func TestClient_Metrics(t *testing.T) {
+	var (
+		httpClient = newMockHttpClient()
+		metrics    = &recordingMetrics{}
+		client     = newTestClient(t,
+			WithHttpClient(httpClient),
+			WithMetrics(metrics),
+			WithRetryPolicy(&RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}),
+		)
+		uri = client.config.baseURL + "/business/payments/0aa5bd35-b969-5d1d-ae7b-dfc0c4abbaf7"
+	)
+
+	httpClient.MockRequest(uri, func() (int, string) {
+		return http.StatusServiceUnavailable, `{"code":"ServiceUnavailable","message":"try again later"}`
+	})
+
+	_, err := client.LookupPayment(context.Background(), "0aa5bd35-b969-5d1d-ae7b-dfc0c4abbaf7")
+	assert.Error(t, err)
+
+	assert.Equal(t, []string{
+		"started:LookupPayment",
+		"finished:LookupPayment",
+		"retried:LookupPayment",
+		"started:LookupPayment",
+		"finished:LookupPayment",
+	}, metrics.events)
+
+	for _, result := range metrics.results {
+		assert.Equal(t, http.StatusServiceUnavailable, result.StatusCode)
+		assert.Equal(t, "ServiceUnavailable", result.ErrorCode)
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		want   string
+	}{
+		{"error response", &errorResponse{Code: "NotFound", StatusCode: 404}, 404, "NotFound"},
+		{"error response without code", errors.New("yellowcard: deserialize error response"), 500, "500"},
+		{"rate limited", ErrRateLimited, 0, ErrorCodeRateLimited},
+		{"circuit open", ErrCircuitOpen, 0, ErrorCodeCircuitOpen},
+		{"deadline exceeded", context.DeadlineExceeded, 0, ErrorCodeDeadlineExceeded},
+		{"canceled", context.Canceled, 0, ErrorCodeCanceled},
+		{"connection error", errors.New("connection reset by peer"), 0, ErrorCodeRequestFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, errorCode(tt.err, tt.status))
+		})
+	}
+}
+
+func TestPrometheusMetrics(t *testing.T) {
+	metrics := NewPrometheusMetrics("", 0.1, 1)
+
+	metrics.RequestStarted(OperationGetRates)
+	metrics.RequestStarted(OperationGetRates)
+	metrics.RequestFinished(OperationGetRates, RequestResult{Latency: 50 * time.Millisecond, StatusCode: 200})
+	metrics.RequestStarted(OperationMakePayment)
+	metrics.RequestFinished(OperationMakePayment, RequestResult{
+		ErrorCode:  "InvalidSender",
+		Latency:    500 * time.Millisecond,
+		StatusCode: 400,
+	})
+	metrics.RequestRetried(OperationGetRates)
+
+	rec := httptest.NewRecorder()
+	metrics.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/plain; version=0.0.4")
+
+	want := []string{
+		`# TYPE yellowcard_requests_total counter`,
+		`yellowcard_requests_total{operation="GetRates",status="200"} 1`,
+		`yellowcard_requests_total{operation="MakePayment",status="400"} 1`,
+		`yellowcard_request_errors_total{operation="MakePayment",code="InvalidSender"} 1`,
+		`yellowcard_request_retries_total{operation="GetRates"} 1`,
+		`# TYPE yellowcard_requests_in_flight gauge`,
+		`yellowcard_requests_in_flight{operation="GetRates"} 1`,
+		`yellowcard_requests_in_flight{operation="MakePayment"} 0`,
+		`# TYPE yellowcard_request_duration_seconds histogram`,
+		`yellowcard_request_duration_seconds_bucket{operation="GetRates",le="0.1"} 1`,
+		`yellowcard_request_duration_seconds_bucket{operation="MakePayment",le="0.1"} 0`,
+		`yellowcard_request_duration_seconds_bucket{operation="MakePayment",le="1"} 1`,
+		`yellowcard_request_duration_seconds_bucket{operation="MakePayment",le="+Inf"} 1`,
+		`yellowcard_request_duration_seconds_sum{operation="MakePayment"} 0.5`,
+		`yellowcard_request_duration_seconds_count{operation="MakePayment"} 1`,
+	}
+
+	body := rec.Body.String()
+	for _, line := range want {
+		assert.Contains(t, strings.Split(body, "\n"), line)
+	}
+}
+
+// expvarRuns numbers the runs of TestExpvarMetrics, as expvar names can only be published once per process.
+var expvarRuns atomic.Int64
+
+func TestExpvarMetrics(t *testing.T) {
+	var (
+		name    = fmt.Sprintf("%s_%d", t.Name(), expvarRuns.Add(1))
+		metrics = NewExpvarMetrics(name)
+	)
+
+	metrics.RequestStarted(OperationGetRates)
+	metrics.RequestFinished(OperationGetRates, RequestResult{
+		ErrorCode:  "ServiceUnavailable",
+		Latency:    250 * time.Millisecond,
+		StatusCode: 503,
+	})
+	metrics.RequestRetried(OperationGetRates)
+	metrics.RequestStarted(OperationGetRates)
+
+	var got map[string]struct {
+		Errors         map[string]int `json:"errors"`
+		InFlight       int            `json:"in_flight"`
+		LatencySeconds float64        `json:"latency_seconds"`
+		Requests       map[string]int `json:"requests"`
+		Retries        int            `json:"retries"`
+	}
+
+	assert.NoError(t, json.Unmarshal([]byte(expvar.Get(name).String()), &got))
+
+	rates := got["GetRates"]
+	assert.Equal(t, map[string]int{"ServiceUnavailable": 1}, rates.Errors)
+	assert.Equal(t, 1, rates.InFlight)
+	assert.Equal(t, 0.25, rates.LatencySeconds)
+	assert.Equal(t, map[string]int{"503": 1}, rates.Requests)
+	assert.Equal(t, 1, rates.Retries)
+}
@@ -0,0 +1,31 @@
+package yellowcard
+
+import (
+	"net/http"
+)
+
+// Handler sends the signed request made for an operation and returns the response.
+type Handler func(op Operation, req *http.Request) (*http.Response, error)
+
+// Middleware wraps a Handler to inspect or modify requests and responses, e.g. for logging, metrics, tracing or
+// header injection. Requests are already signed when they reach a Middleware, so headers it adds do not affect
+// the signature while changes to the method, path or body invalidate it.
+type Middleware func(next Handler) Handler
+
+// WithMiddleware configures the ClientConfig to pass every request through the given middleware. The first
+// middleware is the outermost one and sees each request first. Every attempt made by a RetryPolicy passes
+// through the chain.
+func WithMiddleware(middleware ...Middleware) func(config *ClientConfig) {
+	return func(config *ClientConfig) {
+		config.middleware = append(config.middleware, middleware...)
+	}
+}
+
+// chain wraps the handler with the middleware, the first middleware being the outermost one.
+func chain(handler Handler, middleware []Middleware) Handler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+
+	return handler
+}
@@ -0,0 +1,106 @@
+package yellowcard
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_Middleware(t *testing.T) {
+	var (
+		httpClient = newMockHttpClient()
+		calls      []string
+	)
+
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(op Operation, req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+":"+op.String())
+
+				assert.Contains(t, req.Header.Get("Authorization"), "YcHmacV1 key:")
+				assert.NotEmpty(t, req.Header.Get("X-YC-Timestamp"))
+
+				resp, err := next(op, req)
+				if err == nil {
+					calls = append(calls, name+":"+resp.Status)
+				}
+
+				return resp, err
+			}
+		}
+	}
+
+	injectHeader := func(next Handler) Handler {
+		return func(op Operation, req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-Request-ID", "b6c1f0a2")
+			return next(op, req)
+		}
+	}
+
+	client := newTestClient(t,
+		WithHttpClient(httpClient),
+		WithMiddleware(record("outer"), record("inner")),
+		WithMiddleware(injectHeader),
+	)
+
+	httpClient.MockRequest(client.config.baseURL+"/business/account", func() (int, string) {
+		return http.StatusOK, `{"id":"deb55c03-9961-417a-9550-f5ba7fe258e9"}`
+	})
+
+	account, err := client.GetAccount(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "deb55c03-9961-417a-9550-f5ba7fe258e9", account.ID)
+	assert.Equal(t, []string{"outer:GetAccount", "inner:GetAccount", "inner:OK", "outer:OK"}, calls)
+	assert.Equal(t, "b6c1f0a2", httpClient.requests[0].Header.Get("X-Request-ID"))
+}
+
+func TestClient_MiddlewareRetries(t *testing.T) {
+	var (
+		httpClient = newMockHttpClient()
+		attempts   []string
+		client     = newTestClient(t,
+			WithHttpClient(httpClient),
+			WithRetryPolicy(&RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}),
+			WithMiddleware(func(next Handler) Handler {
+				return func(op Operation, req *http.Request) (*http.Response, error) {
+					resp, err := next(op, req)
+					attempts = append(attempts, resp.Status)
+					return resp, err
+				}
+			}),
+		)
+	)
+
+	httpClient.MockRequest(client.config.baseURL+"/business/account", func() (int, string) {
+		return http.StatusBadGateway, `{"code":"BadGateway","message":"upstream unavailable"}`
+	})
+
+	_, err := client.GetAccount(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, []string{"Bad Gateway", "Bad Gateway"}, attempts)
+}
+
+func TestClient_MiddlewareShortCircuit(t *testing.T) {
+	var (
+		httpClient = newMockHttpClient()
+		client     = newTestClient(t,
+			WithHttpClient(httpClient),
+			WithMiddleware(func(next Handler) Handler {
+				return func(op Operation, req *http.Request) (*http.Response, error) {
+					if op == OperationGetRates {
+						return mockHttpResponse(http.StatusOK, `{"rates":[{"code":"KES","buy":129}]}`), nil
+					}
+
+					return next(op, req)
+				}
+			}),
+		)
+	)
+
+	rates, err := client.GetRates(context.Background(), "")
+	assert.NoError(t, err)
+	assert.Len(t, rates, 1)
+	assert.Empty(t, httpClient.requests)
+}
@@ -11,7 +11,9 @@ import (
 type mockResponseFunc func() (status int, body string)
 
 type mockResponse struct {
-	fn mockResponseFunc
+	fn     mockResponseFunc
+	errFn  func() error
+	header http.Header
 }
 
 type mockHttpClient struct {
@@ -42,18 +44,43 @@ func (m *mockHttpClient) MockRequest(url string, fn mockResponseFunc) {
 	m.responses[url] = mockResponse{fn: fn}
 }
 
+// MockRequestWithHeader appends the given response for the provided url along with its headers.
+func (m *mockHttpClient) MockRequestWithHeader(url string, header http.Header, fn mockResponseFunc) {
+	m.responses[url] = mockResponse{fn: fn, header: header}
+}
+
+// MockRequestError fails requests to the provided url with the error returned by errFn.
+// If errFn returns nil, the response from fn is returned instead.
+func (m *mockHttpClient) MockRequestError(url string, errFn func() error, fn mockResponseFunc) {
+	m.responses[url] = mockResponse{fn: fn, errFn: errFn}
+}
+
 // Do checks if the given req.URL exists in the available responses lists and returns the stored response.
-// If none exists, it returns status http.StatusNotFound
+// If none exists, it returns status http.StatusNotFound. Requests with a done context fail like they would
+// with a http.Client.
 func (m *mockHttpClient) Do(req *http.Request) (*http.Response, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	m.requests = append(m.requests, req.Clone(req.Context()))
 
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+
 	if mock, ok := m.responses[req.URL.String()]; ok {
+		if mock.errFn != nil {
+			if err := mock.errFn(); err != nil {
+				return nil, err
+			}
+		}
+
 		if mock.fn != nil {
 			status, body := mock.fn()
-			return mockHttpResponse(status, body), nil
+
+			resp := mockHttpResponse(status, body)
+			resp.Header = mock.header.Clone()
+			return resp, nil
 		}
 	}
 
@@ -0,0 +1,37 @@
+package yellowcard
+
+// Operation is the logical name of an API call or other operation made by the Client. It matches the name of
+// the Client method making the call, e.g. OperationMakePayment for Client.MakePayment.
+type Operation string
+
+const (
+	OperationListChannels                 Operation = "ListChannels"
+	OperationListNetworks                 Operation = "ListNetworks"
+	OperationGetRates                     Operation = "GetRates"
+	OperationQuote                        Operation = "Quote"
+	OperationResolveBankAccount           Operation = "ResolveBankAccount"
+	OperationResolveMobileMoneyAccount    Operation = "ResolveMobileMoneyAccount"
+	OperationMakePayment                  Operation = "MakePayment"
+	OperationAcceptPaymentRequest         Operation = "AcceptPaymentRequest"
+	OperationDenyPaymentRequest           Operation = "DenyPaymentRequest"
+	OperationLookupPayment                Operation = "LookupPayment"
+	OperationLookupPaymentBySequenceID    Operation = "LookupPaymentBySequenceID"
+	OperationListPayments                 Operation = "ListPayments"
+	OperationSubmitCollectionRequest      Operation = "SubmitCollectionRequest"
+	OperationAcceptCollectionRequest      Operation = "AcceptCollectionRequest"
+	OperationDenyCollectionRequest        Operation = "DenyCollectionRequest"
+	OperationLookupCollection             Operation = "LookupCollection"
+	OperationLookupCollectionBySequenceID Operation = "LookupCollectionBySequenceID"
+	OperationGetAccount                   Operation = "GetAccount"
+	OperationCreateSettlement             Operation = "CreateSettlement"
+	OperationLookupSettlement             Operation = "LookupSettlement"
+	OperationListSettlements              Operation = "ListSettlements"
+	OperationCreateWebhook                Operation = "CreateWebhook"
+	OperationListWebhooks                 Operation = "ListWebhooks"
+	OperationUpdateWebhook                Operation = "UpdateWebhook"
+	OperationRemoveWebhook                Operation = "RemoveWebhook"
+)
+
+func (o Operation) String() string {
+	return string(o)
+}
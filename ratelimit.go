@@ -0,0 +1,142 @@
+package yellowcard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned when a request cannot be sent within the rate limit before its context is done.
+var ErrRateLimited = errors.New("yellowcard: rate limited")
+
+// RateLimit allows Rate requests per second on average with bursts of up to Burst requests.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// WithRateLimit configures the ClientConfig to limit the rate of all requests made by the Client.
+// Requests over the limit block until they can be sent, or fail with ErrRateLimited if the context
+// would expire first. A Rate of zero or less disables the limit.
+func WithRateLimit(limit RateLimit) func(config *ClientConfig) {
+	return func(config *ClientConfig) {
+		config.rateLimiter().global = newTokenBucket(limit)
+	}
+}
+
+// WithOperationRateLimit configures the ClientConfig to limit the rate of requests made for the operation.
+// The limit applies in addition to any limit set with WithRateLimit.
+func WithOperationRateLimit(op Operation, limit RateLimit) func(config *ClientConfig) {
+	return func(config *ClientConfig) {
+		limiter := config.rateLimiter()
+
+		if bucket := newTokenBucket(limit); bucket != nil {
+			limiter.operations[op] = bucket
+		} else {
+			delete(limiter.operations, op)
+		}
+	}
+}
+
+// rateLimiter returns the config's rate limiter, creating it if needed.
+func (c *ClientConfig) rateLimiter() *rateLimiter {
+	if c.limiter == nil {
+		c.limiter = &rateLimiter{operations: make(map[Operation]*tokenBucket), now: time.Now}
+	}
+
+	return c.limiter
+}
+
+// rateLimiter applies a global limit and per operation limits to requests.
+type rateLimiter struct {
+	global     *tokenBucket
+	operations map[Operation]*tokenBucket
+	now        func() time.Time
+}
+
+// wait blocks until a request for the operation is allowed by every applicable limit.
+func (l *rateLimiter) wait(ctx context.Context, op Operation) error {
+	if l == nil {
+		return nil
+	}
+
+	var (
+		now     = l.now()
+		buckets = make([]*tokenBucket, 0, 2)
+		delay   time.Duration
+	)
+
+	for _, bucket := range []*tokenBucket{l.global, l.operations[op]} {
+		if bucket != nil {
+			buckets = append(buckets, bucket)
+			delay = max(delay, bucket.reserve(now))
+		}
+	}
+
+	if delay == 0 {
+		return nil
+	}
+
+	if err := sleep(ctx, delay); err != nil {
+		for _, bucket := range buckets {
+			bucket.cancel()
+		}
+
+		return fmt.Errorf("%w - %w", ErrRateLimited, err)
+	}
+
+	return nil
+}
+
+// tokenBucket is a token bucket refilled at rate tokens per second up to burst tokens.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket returns a full bucket for the limit, or nil if the limit is disabled.
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	if limit.Rate <= 0 {
+		return nil
+	}
+
+	burst := float64(max(limit.Burst, 1))
+
+	return &tokenBucket{
+		rate:   limit.Rate,
+		burst:  burst,
+		tokens: burst,
+	}
+}
+
+// reserve takes a token from the bucket and returns how long to wait before it may be used.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.last.IsZero() {
+		b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	}
+
+	b.last = now
+	b.tokens--
+
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token that will not be used.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = min(b.burst, b.tokens+1)
+}
@@ -0,0 +1,107 @@
+package yellowcard
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket_Reserve(t *testing.T) {
+	var (
+		bucket = newTokenBucket(RateLimit{Rate: 10, Burst: 2})
+		now    = time.Date(2024, time.June, 14, 16, 20, 0, 0, time.UTC)
+	)
+
+	assert.Equal(t, time.Duration(0), bucket.reserve(now))
+	assert.Equal(t, time.Duration(0), bucket.reserve(now))
+	assert.Equal(t, 100*time.Millisecond, bucket.reserve(now))
+	assert.Equal(t, 200*time.Millisecond, bucket.reserve(now))
+
+	// Test cancelled reservations return their token
+	bucket.cancel()
+	assert.Equal(t, 200*time.Millisecond, bucket.reserve(now))
+
+	// Test tokens are refilled over time up to the burst
+	bucket.cancel()
+	bucket.cancel()
+	assert.Equal(t, time.Duration(0), bucket.reserve(now.Add(time.Second)))
+	assert.Equal(t, time.Duration(0), bucket.reserve(now.Add(time.Second)))
+	assert.Equal(t, 100*time.Millisecond, bucket.reserve(now.Add(time.Second)))
+
+	assert.Nil(t, newTokenBucket(RateLimit{}))
+}
+
+func TestClient_RateLimit(t *testing.T) {
+	var (
+		httpClient = newMockHttpClient()
+		client     = newTestClient(t,
+			WithHttpClient(httpClient),
+			WithRateLimit(RateLimit{Rate: 1000, Burst: 10}),
+			WithOperationRateLimit(OperationGetRates, RateLimit{Rate: 0.1, Burst: 1}),
+		)
+		now = time.Date(2024, time.June, 14, 16, 20, 0, 0, time.UTC)
+	)
+
+	client.config.limiter.now = func() time.Time { return now }
+
+	httpClient.MockRequest(client.config.baseURL+"/business/rates", func() (status int, body string) {
+		return http.StatusOK, `{"rates":[]}`
+	})
+
+	httpClient.MockRequest(client.config.baseURL+"/business/account", func() (status int, body string) {
+		return http.StatusOK, `{"balances":[]}`
+	})
+
+	// The deadline is shorter than the 10s wait for a GetRates token, so requests over the limit fail fast
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := client.GetRates(ctx, "")
+	assert.NoError(t, err)
+
+	_, err = client.GetRates(ctx, "")
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.Len(t, httpClient.requests, 1)
+
+	// Test the operation limit does not apply to other operations
+	_, err = client.GetAccount(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, httpClient.requests, 2)
+
+	// Test the token is available once the bucket is refilled
+	now = now.Add(10 * time.Second)
+
+	_, err = client.GetRates(ctx, "")
+	assert.NoError(t, err)
+	assert.Len(t, httpClient.requests, 3)
+}
+
+func TestClient_RateLimitSignsAfterWait(t *testing.T) {
+	var (
+		httpClient = newMockHttpClient()
+		calls      []string
+		client     = newTestClient(t,
+			WithHttpClient(httpClient),
+			WithRateLimit(RateLimit{Rate: 1000, Burst: 1}),
+			WithClock(ClockFunc(func() time.Time {
+				calls = append(calls, "sign")
+				return time.Now()
+			})),
+		)
+	)
+
+	client.config.limiter.now = func() time.Time {
+		calls = append(calls, "wait")
+		return time.Now()
+	}
+
+	httpClient.MockRequest(client.config.baseURL+"/business/rates", func() (status int, body string) {
+		return http.StatusOK, `{"rates":[]}`
+	})
+
+	_, err := client.GetRates(context.Background(), "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"wait", "sign"}, calls[:2])
+}
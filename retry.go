@@ -0,0 +1,136 @@
+package yellowcard
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how requests that fail with a transient error are retried.
+// Connection errors and responses with status 429, 502, 503 or 504 are considered transient.
+// Each attempt is signed again with a fresh X-YC-Timestamp.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts including the first one.
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry. It doubles on every retry up to MaxBackoff.
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts.
+	MaxBackoff time.Duration
+	// RetryUnsafe allows requests of operations in OperationClassMutation, such as submitting a payment, to be
+	// retried. By default, only read and account resolution operations are retried.
+	RetryUnsafe bool
+}
+
+// DefaultRetryPolicy returns a RetryPolicy making up to 3 attempts with a backoff between 250ms and 5s.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  250 * time.Millisecond,
+		MaxBackoff:  5 * time.Second,
+	}
+}
+
+// WithRetryPolicy configures the ClientConfig to retry failed requests using the specified policy.
+// Requests are not retried by default.
+func WithRetryPolicy(policy *RetryPolicy) func(config *ClientConfig) {
+	return func(config *ClientConfig) {
+		config.retryPolicy = policy
+	}
+}
+
+// shouldRetry reports whether a failed attempt should be retried. resp is nil when no response was received.
+func (p *RetryPolicy) shouldRetry(
+	ctx context.Context,
+	op Operation,
+	attempt int,
+	resp *http.Response,
+	err error,
+) bool {
+	if p == nil || attempt >= p.MaxAttempts || ctx.Err() != nil || wasNotSent(err) {
+		return false
+	}
+
+	if !p.RetryUnsafe && !isSafe(op) {
+		return false
+	}
+
+	if resp == nil {
+		return true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns the delay before the next attempt. The exponential delay is jittered to spread out retries
+// from concurrent callers, and a longer Retry-After requested by the server takes precedence.
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	delay := p.MinBackoff << (attempt - 1)
+	if delay <= 0 || (p.MaxBackoff > 0 && delay > p.MaxBackoff) {
+		delay = p.MaxBackoff
+	}
+
+	if delay > 0 {
+		delay = delay/2 + rand.N(delay/2+1)
+	}
+
+	if resp != nil {
+		if retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); retryAfter > delay {
+			delay = retryAfter
+		}
+	}
+
+	return delay
+}
+
+// wasNotSent reports whether err was returned by the Client before the request was sent.
+func wasNotSent(err error) bool {
+	return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrCircuitOpen) || errors.Is(err, ErrCredentialsUnavailable)
+}
+
+// isSafe reports whether the operation only reads data, so that repeating it has no effect on the account.
+func isSafe(op Operation) bool {
+	return op.Class() != OperationClassMutation
+}
+
+// parseRetryAfter parses a Retry-After header value given either in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0)
+	}
+
+	return 0
+}
+
+// sleep waits for the given duration or until the context is done. It returns immediately with
+// context.DeadlineExceeded if the context would expire before the duration elapses.
+func sleep(ctx context.Context, d time.Duration) error {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < d {
+		return context.DeadlineExceeded
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,218 @@
+package yellowcard
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_Retry(t *testing.T) {
+	var (
+		httpClient = newMockHttpClient()
+		policy     = &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+		now        = time.Date(2024, time.June, 14, 16, 20, 0, 0, time.UTC)
+		clock      = ClockFunc(func() time.Time {
+			now = now.Add(time.Second)
+			return now
+		})
+		client = newTestClient(t, WithHttpClient(httpClient), WithRetryPolicy(policy), WithClock(clock))
+		ctx    = context.Background()
+		calls  int
+	)
+
+	httpClient.MockRequest(client.config.baseURL+"/business/rates", func() (status int, body string) {
+		calls++
+		if calls < 3 {
+			return http.StatusServiceUnavailable, `{"code":"ServiceUnavailable","message":"try again"}`
+		}
+
+		return http.StatusOK, `{"rates":[{"code":"KES","buy":129}]}`
+	})
+
+	rates, err := client.GetRates(ctx, "")
+	assert.NoError(t, err)
+	assert.Len(t, rates, 1)
+	assert.Equal(t, 3, calls)
+	assert.Len(t, httpClient.requests, 3)
+
+	// Test every attempt is signed again with a fresh timestamp
+	timestamps := make(map[string]bool)
+	for _, req := range httpClient.requests {
+		assert.Contains(t, req.Header.Get("Authorization"), "YcHmacV1 key:")
+		timestamps[req.Header.Get("X-YC-Timestamp")] = true
+	}
+
+	assert.Len(t, timestamps, 3)
+	assert.NotEqual(t,
+		httpClient.requests[0].Header.Get("Authorization"),
+		httpClient.requests[1].Header.Get("Authorization"),
+	)
+
+	// Test attempts are capped by MaxAttempts
+	calls = -10
+	rates, err = client.GetRates(ctx, "")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "ServiceUnavailable")
+	assert.Nil(t, rates)
+	assert.Equal(t, -7, calls)
+
+	// Test client errors are not retried
+	calls = 0
+	httpClient.MockRequest(client.config.baseURL+"/business/rates", func() (status int, body string) {
+		calls++
+		return http.StatusBadRequest, `{"code":"BadRequest","message":"invalid currency"}`
+	})
+
+	_, err = client.GetRates(ctx, "")
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestClient_RetryConnectionErrors(t *testing.T) {
+	var (
+		httpClient = newMockHttpClient()
+		policy     = &RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+		client     = newTestClient(t, WithHttpClient(httpClient), WithRetryPolicy(policy))
+		paymentID  = "d83011e8-341f-5e3e-b908-84cb4a552fcc"
+		uri        = client.config.baseURL + "/business/payments/" + paymentID
+		ctx        = context.Background()
+		calls      int
+	)
+
+	respond := func() (status int, body string) {
+		return http.StatusOK, `{"id":"d83011e8-341f-5e3e-b908-84cb4a552fcc","status":"complete"}`
+	}
+
+	httpClient.MockRequestError(uri, func() error {
+		calls++
+		if calls == 1 {
+			return errors.New("connection reset by peer")
+		}
+
+		return nil
+	}, respond)
+
+	payment, err := client.LookupPayment(ctx, paymentID)
+	assert.NoError(t, err)
+	assert.Equal(t, paymentID, payment.ID)
+	assert.Equal(t, 2, calls)
+
+	// Test unsafe requests are not retried by default
+	calls = 0
+	httpClient.MockRequestError(uri+"/accept", func() error {
+		calls++
+		return errors.New("connection reset by peer")
+	}, respond)
+
+	payment, err = client.AcceptPaymentRequest(ctx, paymentID)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "connection reset by peer")
+	assert.Nil(t, payment)
+	assert.Equal(t, 1, calls)
+
+	// Test unsafe requests are retried when allowed
+	calls = 0
+	policy.RetryUnsafe = true
+
+	_, err = client.AcceptPaymentRequest(ctx, paymentID)
+	assert.Error(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestClient_RetryByOperation(t *testing.T) {
+	var (
+		httpClient = newMockHttpClient()
+		policy     = &RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+		client     = newTestClient(t, WithHttpClient(httpClient), WithRetryPolicy(policy))
+		ctx        = context.Background()
+		calls      int
+	)
+
+	unavailable := func() (status int, body string) {
+		calls++
+		return http.StatusServiceUnavailable, `{"code":"ServiceUnavailable","message":"try again"}`
+	}
+
+	// Test read-only POST requests are retried
+	httpClient.MockRequest(client.config.baseURL+"/business/details/bank", unavailable)
+
+	_, err := client.ResolveBankAccount(ctx, &ResolveBankAccountRequest{
+		AccountNumber: "589000",
+		NetworkID:     "41109c18-9604-4389-8472-44ff4378c6cb",
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 2, calls)
+
+	// Test PUT and DELETE mutations are not retried by default
+	calls = 0
+	httpClient.MockRequest(client.config.baseURL+"/business/webhooks", unavailable)
+
+	_, err = client.UpdateWebhook(ctx, "ae5ab4d5-3b6f-4b5b-9c0c-5b0b3c3b0f0d", &WebhookRequest{
+		URL: "https://example.com/webhooks/yellowcard",
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+
+	calls = 0
+	httpClient.MockRequest(client.config.baseURL+"/business/webhooks/ae5ab4d5-3b6f-4b5b-9c0c-5b0b3c3b0f0d", unavailable)
+
+	err = client.RemoveWebhook(ctx, "ae5ab4d5-3b6f-4b5b-9c0c-5b0b3c3b0f0d")
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestClient_RetryAfter(t *testing.T) {
+	var (
+		httpClient = newMockHttpClient()
+		policy     = &RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+		client     = newTestClient(t, WithHttpClient(httpClient), WithRetryPolicy(policy))
+		header     = http.Header{"Retry-After": []string{"1"}}
+		calls      int
+	)
+
+	httpClient.MockRequestWithHeader(client.config.baseURL+"/business/rates", header, func() (status int, body string) {
+		calls++
+		return http.StatusTooManyRequests, `{"code":"TooManyRequests","message":"slow down"}`
+	})
+
+	// Test retries are abandoned when Retry-After exceeds the context deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetRates(ctx, "")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "TooManyRequests")
+	assert.Equal(t, 1, calls)
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 5, MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for attempt, want := range map[int]time.Duration{1: 100, 2: 200, 3: 400, 4: 800, 5: 1000} {
+		want *= time.Millisecond
+
+		got := policy.backoff(attempt, nil)
+		assert.GreaterOrEqual(t, got, want/2)
+		assert.LessOrEqual(t, got, want)
+	}
+
+	resp := mockHttpResponse(http.StatusServiceUnavailable, "")
+	resp.Header = http.Header{"Retry-After": []string{"3"}}
+	assert.Equal(t, 3*time.Second, policy.backoff(1, resp))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, time.June, 14, 16, 20, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Duration(0), parseRetryAfter("", now))
+	assert.Equal(t, 2*time.Second, parseRetryAfter("2", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("-2", now))
+	assert.Equal(t, 30*time.Second, parseRetryAfter("Fri, 14 Jun 2024 16:20:30 GMT", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("Fri, 14 Jun 2024 16:19:30 GMT", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon", now))
+}
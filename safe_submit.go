@@ -0,0 +1,108 @@
+package yellowcard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// _safeSubmitLookupTimeout bounds the payment lookup made after the caller's context has expired.
+const _safeSubmitLookupTimeout = 30 * time.Second
+
+// ErrSequenceIDRequired is returned when a payment is submitted safely without a sequence ID.
+var ErrSequenceIDRequired = errors.New("yellowcard: sequence ID is required")
+
+// WithSafePaymentSubmit configures MakePayment to recover from failures that leave the outcome of a submission
+// unknown, such as timeouts, connection errors and 5xx responses. After such a failure, the payment is looked up
+// by its SequenceID and returned if it exists, otherwise it is resubmitted, up to maxAttempts submissions.
+// A response rejecting the SequenceID as a duplicate is treated as success and the existing payment is returned.
+func WithSafePaymentSubmit(maxAttempts int) func(config *ClientConfig) {
+	return func(config *ClientConfig) {
+		config.safeSubmitAttempts = max(maxAttempts, 0)
+	}
+}
+
+// safeSubmitPayment submits the payment request, using its SequenceID to avoid creating the payment twice.
+func (cl *Client) safeSubmitPayment(ctx context.Context, req *PaymentRequest) (*Payment, error) {
+	if req.SequenceID == "" {
+		return nil, ErrSequenceIDRequired
+	}
+
+	policy := cl.config.retryPolicy
+	if policy == nil {
+		policy = DefaultRetryPolicy()
+	}
+
+	for attempt := 1; ; attempt++ {
+		payment, err := cl.submitPayment(ctx, req)
+		if err == nil {
+			return payment, nil
+		}
+
+		if isDuplicateSequence(err) {
+			return cl.LookupPaymentBySequenceID(ctx, req.SequenceID)
+		}
+
+		if !isAmbiguous(err) {
+			return nil, err
+		}
+
+		existing, lookupErr := cl.lookupSubmittedPayment(ctx, req.SequenceID)
+		switch {
+		case lookupErr == nil:
+			return existing, nil
+		case !isNotFound(lookupErr), attempt >= cl.config.safeSubmitAttempts:
+			return nil, err
+		}
+
+		if waitErr := sleep(ctx, policy.backoff(attempt, nil)); waitErr != nil {
+			return nil, err
+		}
+	}
+}
+
+// lookupSubmittedPayment looks up a payment by sequence ID. If ctx has already expired, which is the usual cause
+// of an ambiguous failure, the lookup is made on a detached context bounded by _safeSubmitLookupTimeout.
+func (cl *Client) lookupSubmittedPayment(ctx context.Context, sequenceID string) (*Payment, error) {
+	if ctx.Err() != nil {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(context.WithoutCancel(ctx), _safeSubmitLookupTimeout)
+		defer cancel()
+	}
+
+	return cl.LookupPaymentBySequenceID(ctx, sequenceID)
+}
+
+// isAmbiguous reports whether the request may have been processed despite failing. This is the case unless the
+// request was never sent or the API responded with a client error.
+func isAmbiguous(err error) bool {
+	if wasNotSent(err) {
+		return false
+	}
+
+	var errResp *errorResponse
+	if errors.As(err, &errResp) {
+		return errResp.StatusCode >= http.StatusInternalServerError
+	}
+
+	return true
+}
+
+// isNotFound reports whether the API responded with http.StatusNotFound.
+func isNotFound(err error) bool {
+	var errResp *errorResponse
+	return errors.As(err, &errResp) && errResp.StatusCode == http.StatusNotFound
+}
+
+// isDuplicateSequence reports whether the API rejected a request because its sequence ID was already used.
+func isDuplicateSequence(err error) bool {
+	var errResp *errorResponse
+	if !errors.As(err, &errResp) {
+		return false
+	}
+
+	return errResp.StatusCode == http.StatusConflict || strings.Contains(strings.ToLower(errResp.Code), "duplicate")
+}
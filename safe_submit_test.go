@@ -0,0 +1,170 @@
+package yellowcard
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_SafeMakePayment(t *testing.T) {
+	var (
+		paymentJSON = `{"id":"0aa5bd35-b969-5d1d-ae7b-dfc0c4abbaf7","sequenceId":"nsahHJODjx","status":"created"}`
+		policy      = &RetryPolicy{MaxAttempts: 1, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+		ctx         = context.Background()
+	)
+
+	newPaymentRequest := func() *PaymentRequest {
+		return &PaymentRequest{
+			Amount:     7491.65,
+			ChannelID:  "81018280-e320-4c81-9b2f-6f636c2239d8",
+			SequenceID: "nsahHJODjx",
+		}
+	}
+
+	tests := []struct {
+		name        string
+		submit      []func() (int, string, error)
+		lookup      func() (status int, body string)
+		wantErr     string
+		wantSubmits int
+		wantLookups int
+	}{
+		{
+			name: "Tests payment is resubmitted when it does not exist",
+			submit: []func() (int, string, error){
+				func() (int, string, error) {
+					return http.StatusServiceUnavailable, `{"code":"ServiceUnavailable","message":"try again"}`, nil
+				},
+				func() (int, string, error) { return http.StatusOK, paymentJSON, nil },
+			},
+			lookup: func() (int, string) {
+				return http.StatusNotFound, `{"code":"PaymentNotFound","message":"payment not found"}`
+			},
+			wantSubmits: 2,
+			wantLookups: 1,
+		},
+		{
+			name: "Tests existing payment is returned after a connection error",
+			submit: []func() (int, string, error){
+				func() (int, string, error) { return 0, "", errors.New("connection reset by peer") },
+			},
+			lookup:      func() (int, string) { return http.StatusOK, paymentJSON },
+			wantSubmits: 1,
+			wantLookups: 1,
+		},
+		{
+			name: "Tests duplicate sequence ID returns the existing payment",
+			submit: []func() (int, string, error){
+				func() (int, string, error) {
+					return http.StatusConflict, `{"code":"DuplicateSequenceId","message":"sequence id already used"}`, nil
+				},
+			},
+			lookup:      func() (int, string) { return http.StatusOK, paymentJSON },
+			wantSubmits: 1,
+			wantLookups: 1,
+		},
+		{
+			name: "Tests client errors are returned without lookup",
+			submit: []func() (int, string, error){
+				func() (int, string, error) {
+					return http.StatusBadRequest, `{"code":"InvalidChannel","message":"channel is not active"}`, nil
+				},
+			},
+			wantErr:     "InvalidChannel",
+			wantSubmits: 1,
+		},
+		{
+			name: "Tests attempts are capped",
+			submit: []func() (int, string, error){
+				func() (int, string, error) { return 0, "", errors.New("i/o timeout") },
+				func() (int, string, error) { return 0, "", errors.New("i/o timeout") },
+				func() (int, string, error) { return 0, "", errors.New("i/o timeout") },
+			},
+			lookup: func() (int, string) {
+				return http.StatusNotFound, `{"code":"PaymentNotFound","message":"payment not found"}`
+			},
+			wantErr:     "i/o timeout",
+			wantSubmits: 3,
+			wantLookups: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				httpClient = newMockHttpClient()
+				client     = newTestClient(t,
+					WithHttpClient(httpClient),
+					WithRetryPolicy(policy),
+					WithSafePaymentSubmit(3),
+				)
+				submits, lookups int
+				status           int
+				body             string
+			)
+
+			httpClient.MockRequestError(client.config.baseURL+"/business/payments", func() error {
+				var err error
+				status, body, err = tt.submit[submits]()
+				submits++
+				return err
+			}, func() (int, string) {
+				return status, body
+			})
+
+			if tt.lookup != nil {
+				uri := client.config.baseURL + "/business/payments/sequence-id/nsahHJODjx"
+				httpClient.MockRequest(uri, func() (int, string) {
+					lookups++
+					return tt.lookup()
+				})
+			}
+
+			payment, err := client.MakePayment(ctx, newPaymentRequest(), true)
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Nil(t, payment)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "0aa5bd35-b969-5d1d-ae7b-dfc0c4abbaf7", payment.ID)
+			}
+
+			assert.Equal(t, tt.wantSubmits, submits)
+			assert.Equal(t, tt.wantLookups, lookups)
+		})
+	}
+
+	// Test sequence ID is required
+	client := newTestClient(t, WithHttpClient(newMockHttpClient()), WithSafePaymentSubmit(3))
+
+	payment, err := client.MakePayment(ctx, &PaymentRequest{Amount: 10}, true)
+	assert.EqualError(t, ErrSequenceIDRequired, err.Error())
+	assert.Nil(t, payment)
+}
+
+func TestClient_SafeMakePaymentExpiredContext(t *testing.T) {
+	var (
+		httpClient = newMockHttpClient()
+		client     = newTestClient(t, WithHttpClient(httpClient), WithSafePaymentSubmit(3))
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	httpClient.MockRequestError(client.config.baseURL+"/business/payments", func() error {
+		cancel()
+		return context.Canceled
+	}, nil)
+
+	httpClient.MockRequest(client.config.baseURL+"/business/payments/sequence-id/nsahHJODjx", func() (int, string) {
+		return http.StatusOK, `{"id":"0aa5bd35-b969-5d1d-ae7b-dfc0c4abbaf7","sequenceId":"nsahHJODjx"}`
+	})
+
+	payment, err := client.MakePayment(ctx, &PaymentRequest{SequenceID: "nsahHJODjx"}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "0aa5bd35-b969-5d1d-ae7b-dfc0c4abbaf7", payment.ID)
+	assert.Len(t, httpClient.requests, 2)
+}
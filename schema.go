@@ -1,7 +1,9 @@
 package yellowcard
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -13,6 +15,16 @@ const (
 	AccountTypeMobileMoney AccountType = "momo"
 )
 
+// RampType identifies the direction of funds through a Channel.
+type RampType string
+
+const (
+	// RampTypeDeposit channels are used for collections.
+	RampTypeDeposit RampType = "deposit"
+	// RampTypeWithdraw channels are used for payments.
+	RampTypeWithdraw RampType = "withdraw"
+)
+
 // CustomerType identifies the type of customer making the transaction.
 type CustomerType string
 
@@ -21,6 +33,60 @@ const (
 	CustomerTypeRetail      CustomerType = "retail"
 )
 
+// EventType identifies the kind of event delivered to a webhook.
+type EventType string
+
+const (
+	EventPaymentPending    EventType = "PAYMENT.PENDING"
+	EventPaymentProcessing EventType = "PAYMENT.PROCESSING"
+	EventPaymentComplete   EventType = "PAYMENT.COMPLETE"
+	EventPaymentFailed     EventType = "PAYMENT.FAILED"
+
+	EventCollectionPending    EventType = "COLLECTION.PENDING"
+	EventCollectionProcessing EventType = "COLLECTION.PROCESSING"
+	EventCollectionComplete   EventType = "COLLECTION.COMPLETE"
+	EventCollectionFailed     EventType = "COLLECTION.FAILED"
+)
+
+// IsPayment reports whether the event relates to a payment.
+func (e EventType) IsPayment() bool {
+	return strings.HasPrefix(e.normalize(), "PAYMENT.")
+}
+
+// IsCollection reports whether the event relates to a collection.
+func (e EventType) IsCollection() bool {
+	return strings.HasPrefix(e.normalize(), "COLLECTION.")
+}
+
+// normalize returns the event in the upper case form used by the EventType constants.
+func (e EventType) normalize() string {
+	return strings.ToUpper(string(e))
+}
+
+// CryptoNetwork identifies the blockchain network a stablecoin is transferred on.
+type CryptoNetwork string
+
+const (
+	CryptoNetworkArbitrum CryptoNetwork = "ARBITRUM"
+	CryptoNetworkBEP20    CryptoNetwork = "BEP20"
+	CryptoNetworkCelo     CryptoNetwork = "CELO"
+	CryptoNetworkERC20    CryptoNetwork = "ERC20"
+	CryptoNetworkPolygon  CryptoNetwork = "POLYGON"
+	CryptoNetworkSolana   CryptoNetwork = "SOL"
+	CryptoNetworkStellar  CryptoNetwork = "XLM"
+	CryptoNetworkTRC20    CryptoNetwork = "TRC20"
+)
+
+// SettlementType identifies the direction of funds in a settlement.
+type SettlementType string
+
+const (
+	// SettlementTypeTopUp funds the business balance with a stablecoin transfer.
+	SettlementTypeTopUp SettlementType = "topup"
+	// SettlementTypeWithdrawal sends funds from the business balance to a stablecoin wallet.
+	SettlementTypeWithdrawal SettlementType = "withdrawal"
+)
+
 // errorResponse represents an error response received from the API.
 type errorResponse struct {
 	Code       string `json:"code"`
@@ -32,7 +98,12 @@ func (e errorResponse) String() string {
 	return fmt.Sprintf("yellowcard: request failed with status [%d] %s: %s", e.StatusCode, e.Code, e.Message)
 }
 
-// Channel is specific financial mechanism used to facilitate a payment.
+func (e errorResponse) Error() string {
+	return e.String()
+}
+
+// Channel is specific financial mechanism used to facilitate a payment. FeeLocal and FeeUSD are not documented as
+// separate charges, so they are treated as the same channel fee expressed in the local currency and in USD.
 type Channel struct {
 	ApiStatus               string    `json:"apiStatus"`
 	Balancer                any       `json:"balancer"`
@@ -42,8 +113,8 @@ type Channel struct {
 	CreatedAt               time.Time `json:"createdAt"`
 	Currency                string    `json:"currency"`
 	EstimatedSettlementTime int       `json:"estimatedSettlementTime"`
-	FeeLocal                int       `json:"feeLocal"`
-	FeeUSD                  int       `json:"feeUSD"`
+	FeeLocal                float64   `json:"feeLocal"`
+	FeeUSD                  float64   `json:"feeUSD"`
 	ID                      string    `json:"id"`
 	Max                     int       `json:"max"`
 	Min                     float64   `json:"min"`
@@ -60,6 +131,17 @@ type ChannelResponse struct {
 	Channels []*Channel `json:"channels"`
 }
 
+// ChannelOptions narrows down the channels returned by Client.ListChannels. Zero values are ignored.
+type ChannelOptions struct {
+	// APIStatus only returns channels whose apiStatus matches, e.g. "active".
+	APIStatus   string
+	ChannelType AccountType
+	Country     CountryCode
+	// IncludeInactive returns channels regardless of their status. By default only active channels are returned.
+	IncludeInactive bool
+	RampType        RampType
+}
+
 // Network is a company, bank, or service that the end-user interfaces financially with.
 // There can be multiple Channel(s) linked to a Network.
 type Network struct {
@@ -79,6 +161,14 @@ type NetworksResponse struct {
 	Networks []*Network `json:"networks"`
 }
 
+// NetworkOptions narrows down the networks returned by Client.ListNetworks. Zero values are ignored.
+type NetworkOptions struct {
+	AccountNumberType AccountType
+	Country           CountryCode
+	// IncludeInactive returns networks regardless of their status. By default only active networks are returned.
+	IncludeInactive bool
+}
+
 // Rate represents currency exchange rate information.
 type Rate struct {
 	Buy       float64   `json:"buy,omitempty"`
@@ -93,6 +183,42 @@ type RatesResponse struct {
 	Rates []*Rate `json:"rates"`
 }
 
+// QuoteRequest describes an amount to be sent or collected through a Channel.
+// Either Amount or LocalAmount should be set.
+type QuoteRequest struct {
+	// Amount is the amount in USD.
+	Amount    float64
+	ChannelID string
+	Country   CountryCode
+	// Currency is the local currency of the quote. Defaults to the currency of Country.
+	Currency CurrencyCode
+	// LocalAmount is the amount in the local currency.
+	LocalAmount float64
+}
+
+// Quote is the cost of sending or collecting an amount through a Channel at the current Rate.
+type Quote struct {
+	// Amount is the amount in USD.
+	Amount    float64
+	ChannelID string
+	// ConvertedAmount is Amount converted to the local currency.
+	ConvertedAmount float64
+	Currency        CurrencyCode
+	// FeeLocal is the channel fee in the local currency. It is Channel.FeeLocal when set, otherwise Channel.FeeUSD
+	// converted with Rate, as both fields express the same fee.
+	FeeLocal float64
+	// FeeUSD is FeeLocal converted to USD with Rate.
+	FeeUSD   float64
+	RampType RampType
+	// Rate is the number of local currency units per USD used for the conversion.
+	Rate   float64
+	RateID string
+	// TotalLocal is ConvertedAmount plus FeeLocal.
+	TotalLocal float64
+	// TotalUSD is Amount plus FeeUSD.
+	TotalUSD float64
+}
+
 // ResolveBankAccountRequest validates a bank account before sending the money.
 type ResolveBankAccountRequest struct {
 	AccountNumber string `json:"accountNumber"`
@@ -105,6 +231,19 @@ type ResolveBankAccountResponse struct {
 	AccountNumber string `json:"accountNumber"`
 }
 
+// ResolveMobileMoneyAccountRequest validates a mobile money account before sending the money.
+type ResolveMobileMoneyAccountRequest struct {
+	// AccountNumber is the phone number registered with the mobile money network, in international format.
+	AccountNumber string `json:"accountNumber"`
+	NetworkID     string `json:"networkId"`
+}
+
+type ResolveMobileMoneyAccountResponse struct {
+	AccountName   string `json:"accountName"`
+	AccountNumber string `json:"accountNumber"`
+	NetworkName   string `json:"networkName"`
+}
+
 type Destination struct {
 	AccountBank   string      `json:"accountBank"`
 	AccountName   string      `json:"accountName"`
@@ -126,9 +265,20 @@ type Sender struct {
 	Phone    string `json:"phone"`
 }
 
+// BusinessSender is the sender of a payment made on behalf of an institution.
+type BusinessSender struct {
+	Address string `json:"address"`
+	// BusinessID is the registration number of the business.
+	BusinessID   string `json:"businessId"`
+	BusinessName string `json:"businessName"`
+	Country      string `json:"country"`
+}
+
 type PaymentRequest struct {
-	Amount    float64 `json:"amount"`
-	ChannelID string  `json:"channelId"`
+	Amount float64 `json:"amount"`
+	// BusinessSender is sent as the sender when CustomerType is CustomerTypeInstitution.
+	BusinessSender *BusinessSender `json:"-"`
+	ChannelID      string          `json:"channelId"`
 	// CustomerType determines the type of validation that is performed on the sender.
 	// If value is institution, the sender request object will be validated to ensure
 	// it includes businessName and businessId parameter.
@@ -138,31 +288,294 @@ type PaymentRequest struct {
 	Destination  Destination  `json:"destination"`
 	ForceAccept  bool         `json:"forceAccept"`
 	Reason       string       `json:"reason"`
-	Sender       Sender       `json:"sender"`
-	SequenceID   string       `json:"sequenceId"`
+	// Sender is sent as the sender when CustomerType is CustomerTypeRetail.
+	Sender     Sender `json:"sender"`
+	SequenceID string `json:"sequenceId"`
+}
+
+// MarshalJSON serializes Sender or BusinessSender as the sender depending on the CustomerType.
+func (r PaymentRequest) MarshalJSON() ([]byte, error) {
+	type paymentRequest PaymentRequest
+
+	if r.CustomerType != CustomerTypeInstitution {
+		return json.Marshal(paymentRequest(r))
+	}
+
+	return json.Marshal(struct {
+		paymentRequest
+		Sender *BusinessSender `json:"sender"`
+	}{
+		paymentRequest: paymentRequest(r),
+		Sender:         r.BusinessSender,
+	})
 }
 
 type Payment struct {
-	Amount                float64     `json:"amount"`
-	ChannelID             string      `json:"channelId"`
-	ConvertedAmount       float64     `json:"convertedAmount"`
-	Country               string      `json:"country"`
-	CreatedAt             time.Time   `json:"createdAt"`
-	Currency              string      `json:"currency"`
-	Destination           Destination `json:"destination"`
-	DirectSettlement      bool        `json:"directSettlement"`
-	ExpiresAt             time.Time   `json:"expiresAt"`
-	ForceAccept           bool        `json:"forceAccept"`
-	ID                    string      `json:"id"`
-	PartnerID             string      `json:"partnerId"`
-	Rate                  float64     `json:"rate"`
-	Reason                string      `json:"reason"`
-	RequestSource         string      `json:"requestSource"`
-	Sender                Sender      `json:"sender"`
-	SequenceID            string      `json:"sequenceId"`
-	ServiceFeeAmountLocal float64     `json:"serviceFeeAmountLocal"`
-	ServiceFeeAmountUSD   float64     `json:"serviceFeeAmountUSD"`
-	SettlementInfo        any         `json:"settlementInfo"`
-	Status                string      `json:"status"`
-	UpdatedAt             time.Time   `json:"updatedAt"`
+	Amount float64 `json:"amount"`
+	// BusinessSender is set when the payment was made on behalf of an institution.
+	BusinessSender        *BusinessSender `json:"-"`
+	ChannelID             string          `json:"channelId"`
+	ConvertedAmount       float64         `json:"convertedAmount"`
+	Country               string          `json:"country"`
+	CreatedAt             time.Time       `json:"createdAt"`
+	Currency              string          `json:"currency"`
+	Destination           Destination     `json:"destination"`
+	DirectSettlement      bool            `json:"directSettlement"`
+	ExpiresAt             time.Time       `json:"expiresAt"`
+	ForceAccept           bool            `json:"forceAccept"`
+	ID                    string          `json:"id"`
+	PartnerID             string          `json:"partnerId"`
+	Rate                  float64         `json:"rate"`
+	Reason                string          `json:"reason"`
+	RequestSource         string          `json:"requestSource"`
+	Sender                Sender          `json:"sender"`
+	SequenceID            string          `json:"sequenceId"`
+	ServiceFeeAmountLocal float64         `json:"serviceFeeAmountLocal"`
+	ServiceFeeAmountUSD   float64         `json:"serviceFeeAmountUSD"`
+	SettlementInfo        *SettlementInfo `json:"settlementInfo"`
+	Status                string          `json:"status"`
+	UpdatedAt             time.Time       `json:"updatedAt"`
+}
+
+// SettlementInfo holds the on-chain details of a directly settled payment or collection.
+type SettlementInfo struct {
+	CryptoAmount   float64       `json:"cryptoAmount,omitempty"`
+	CryptoCurrency string        `json:"cryptoCurrency,omitempty"`
+	CryptoNetwork  CryptoNetwork `json:"cryptoNetwork,omitempty"`
+	TxHash         string        `json:"txHash,omitempty"`
+	WalletAddress  string        `json:"walletAddress,omitempty"`
+}
+
+// UnmarshalJSON deserializes the payment, decoding the sender into BusinessSender as well when it
+// describes an institution.
+func (p *Payment) UnmarshalJSON(data []byte) error {
+	type payment Payment
+
+	if err := json.Unmarshal(data, (*payment)(p)); err != nil {
+		return err
+	}
+
+	var raw struct {
+		Sender *BusinessSender `json:"sender"`
+	}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	if raw.Sender != nil && (raw.Sender.BusinessName != "" || raw.Sender.BusinessID != "") {
+		p.BusinessSender = raw.Sender
+	}
+
+	return nil
+}
+
+type PaymentsResponse struct {
+	Payments []*Payment `json:"payments"`
+}
+
+// ListPaymentsFilter narrows down the payments returned by Client.ListPayments. Zero values are ignored.
+type ListPaymentsFilter struct {
+	ChannelID string
+	// EndDate excludes payments created after the given time.
+	EndDate time.Time
+	// Page is the page to retrieve, starting from 1.
+	Page int
+	// PerPage is the maximum number of payments returned per page. Defaults to DefaultPerPage.
+	PerPage    int
+	SequenceID string
+	// StartDate excludes payments created before the given time.
+	StartDate time.Time
+	Status    string
+}
+
+// Balance is the amount the business holds in a single currency.
+type Balance struct {
+	// Available is the amount that can be used to fund payments.
+	Available float64 `json:"available"`
+	Currency  string  `json:"currency"`
+	// Pending is the amount reserved by payments that are still being processed.
+	Pending float64 `json:"pending"`
+}
+
+// Account holds the details and balances of the business account tied to the API key.
+type Account struct {
+	Balances  []*Balance `json:"balances"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Status    string     `json:"status"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+// Balance returns the balance held in the given currency, or nil if the account holds none.
+func (a *Account) Balance(currency string) *Balance {
+	for _, balance := range a.Balances {
+		if strings.EqualFold(balance.Currency, currency) {
+			return balance
+		}
+	}
+
+	return nil
+}
+
+// SettlementRequest moves funds between the business balance and a stablecoin wallet.
+type SettlementRequest struct {
+	// Amount is the amount of CryptoCurrency to transfer.
+	Amount         float64       `json:"amount"`
+	CryptoCurrency string        `json:"cryptoCurrency"`
+	CryptoNetwork  CryptoNetwork `json:"cryptoNetwork"`
+	SequenceID     string        `json:"sequenceId"`
+	// TxHash is the hash of the transfer that funds a top-up, if it has already been sent.
+	TxHash string         `json:"txHash,omitempty"`
+	Type   SettlementType `json:"type"`
+	// WalletAddress is the wallet funds are sent from for a top-up, or sent to for a withdrawal.
+	WalletAddress string `json:"walletAddress"`
+}
+
+type Settlement struct {
+	Amount         float64       `json:"amount"`
+	AmountUSD      float64       `json:"amountUSD"`
+	CreatedAt      time.Time     `json:"createdAt"`
+	CryptoCurrency string        `json:"cryptoCurrency"`
+	CryptoNetwork  CryptoNetwork `json:"cryptoNetwork"`
+	// DepositAddress is the wallet a top-up should be sent to.
+	DepositAddress string         `json:"depositAddress,omitempty"`
+	ExpiresAt      time.Time      `json:"expiresAt"`
+	ID             string         `json:"id"`
+	PartnerID      string         `json:"partnerId"`
+	SequenceID     string         `json:"sequenceId"`
+	Status         string         `json:"status"`
+	TxHash         string         `json:"txHash,omitempty"`
+	Type           SettlementType `json:"type"`
+	UpdatedAt      time.Time      `json:"updatedAt"`
+	WalletAddress  string         `json:"walletAddress"`
+}
+
+type SettlementsResponse struct {
+	Settlements []*Settlement `json:"settlements"`
+}
+
+// ListSettlementsFilter narrows down the settlements returned by Client.ListSettlements. Zero values are ignored.
+type ListSettlementsFilter struct {
+	// Page is the page to retrieve, starting from 1.
+	Page int
+	// PerPage is the maximum number of settlements returned per page. Defaults to DefaultPerPage.
+	PerPage int
+	Status  string
+	Type    SettlementType
+}
+
+// Recipient is the customer whose funds are being collected.
+type Recipient struct {
+	Address  string `json:"address"`
+	Country  string `json:"country"`
+	Dob      string `json:"dob"`
+	Email    string `json:"email"`
+	IDNumber string `json:"idNumber"`
+	IDType   string `json:"idType"`
+	Name     string `json:"name"`
+	Phone    string `json:"phone"`
+}
+
+// Source is the account the collected funds are pulled from.
+type Source struct {
+	AccountNumber string      `json:"accountNumber,omitempty"`
+	AccountType   AccountType `json:"accountType"`
+	NetworkID     string      `json:"networkId,omitempty"`
+}
+
+// BankInfo holds the account details a customer should deposit into to complete a bank collection.
+type BankInfo struct {
+	AccountName   string `json:"accountName"`
+	AccountNumber string `json:"accountNumber"`
+	Name          string `json:"name"`
+}
+
+type CollectionRequest struct {
+	// Amount is the amount in USD. Either Amount or LocalAmount should be set.
+	Amount    float64 `json:"amount,omitempty"`
+	ChannelID string  `json:"channelId"`
+	// CustomerType determines the type of validation that is performed on the recipient.
+	CustomerType CustomerType `json:"customerType"`
+	ForceAccept  bool         `json:"forceAccept"`
+	// LocalAmount is the amount in the local currency of the channel.
+	LocalAmount float64   `json:"localAmount,omitempty"`
+	Recipient   Recipient `json:"recipient"`
+	RedirectURL string    `json:"redirectUrl,omitempty"`
+	SequenceID  string    `json:"sequenceId"`
+	Source      Source    `json:"source"`
+}
+
+type Collection struct {
+	Amount                float64         `json:"amount"`
+	BankInfo              *BankInfo       `json:"bankInfo,omitempty"`
+	ChannelID             string          `json:"channelId"`
+	ConvertedAmount       float64         `json:"convertedAmount"`
+	Country               string          `json:"country"`
+	CreatedAt             time.Time       `json:"createdAt"`
+	Currency              string          `json:"currency"`
+	CustomerType          string          `json:"customerType"`
+	DepositedAmount       float64         `json:"depositedAmount"`
+	DirectSettlement      bool            `json:"directSettlement"`
+	ExpiresAt             time.Time       `json:"expiresAt"`
+	ForceAccept           bool            `json:"forceAccept"`
+	ID                    string          `json:"id"`
+	PartnerID             string          `json:"partnerId"`
+	Rate                  float64         `json:"rate"`
+	Recipient             Recipient       `json:"recipient"`
+	Reference             string          `json:"reference"`
+	RequestSource         string          `json:"requestSource"`
+	SequenceID            string          `json:"sequenceId"`
+	ServiceFeeAmountLocal float64         `json:"serviceFeeAmountLocal"`
+	ServiceFeeAmountUSD   float64         `json:"serviceFeeAmountUSD"`
+	SettlementInfo        *SettlementInfo `json:"settlementInfo"`
+	Source                Source          `json:"source"`
+	Status                string          `json:"status"`
+	UpdatedAt             time.Time       `json:"updatedAt"`
+}
+
+// WebhookRequest registers a URL to be notified of events.
+type WebhookRequest struct {
+	Active bool `json:"active"`
+	// Events limits the notifications sent to the webhook. All events are sent when empty.
+	Events []EventType `json:"events,omitempty"`
+	URL    string      `json:"url"`
+}
+
+// Webhook is a URL registered to receive event notifications.
+type Webhook struct {
+	Active    bool        `json:"active"`
+	CreatedAt time.Time   `json:"createdAt"`
+	Events    []EventType `json:"events,omitempty"`
+	ID        string      `json:"id"`
+	PartnerID string      `json:"partnerId,omitempty"`
+	UpdatedAt time.Time   `json:"updatedAt"`
+	URL       string      `json:"url"`
+}
+
+type WebhooksResponse struct {
+	Webhooks []*Webhook `json:"webhooks"`
+}
+
+// WebhookEvent is a notification delivered by Yellow Card to a registered webhook.
+type WebhookEvent struct {
+	APIKey string    `json:"apiKey"`
+	Event  EventType `json:"event"`
+	// ExecutedAt is the time the event was emitted in milliseconds since the Unix epoch.
+	ExecutedAt int64  `json:"executedAt"`
+	ID         string `json:"id"`
+	SequenceID string `json:"sequenceId"`
+	SessionID  string `json:"sessionId,omitempty"`
+	Status     string `json:"status"`
+
+	// Collection is decoded from the payload of collection events and is nil otherwise.
+	Collection *Collection `json:"-"`
+	// Payment is decoded from the payload of payment events and is nil otherwise.
+	Payment *Payment `json:"-"`
+}
+
+// Time returns the time the event was emitted.
+func (e *WebhookEvent) Time() time.Time {
+	return time.UnixMilli(e.ExecutedAt).UTC()
 }
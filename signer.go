@@ -0,0 +1,197 @@
+package yellowcard
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// AuthorizationScheme is the scheme of the Authorization header of signed requests.
+	AuthorizationScheme = "YcHmacV1"
+
+	// TimestampHeader is the header carrying the time a request was signed at.
+	TimestampHeader = "X-YC-Timestamp"
+
+	// DefaultSignatureTolerance is the maximum difference between the time a request was signed at and the time
+	// it is verified at accepted by a Verifier.
+	DefaultSignatureTolerance = 5 * time.Minute
+)
+
+// ErrMissingSignature is returned when a request has no valid YcHmacV1 Authorization or X-YC-Timestamp header.
+var ErrMissingSignature = errors.New("yellowcard: missing request signature")
+
+// ErrUnknownKey is returned when a request is signed with an API key the Verifier does not know.
+var ErrUnknownKey = errors.New("yellowcard: unknown API key")
+
+// ErrInvalidSignature is returned when the signature of a request does not match its content.
+var ErrInvalidSignature = errors.New("yellowcard: invalid request signature")
+
+// ErrInvalidTimestamp is returned when a request was signed outside the tolerance of the Verifier.
+var ErrInvalidTimestamp = errors.New("yellowcard: request timestamp outside tolerance")
+
+// Signer signs requests to the API, e.g. from a gateway proxying them.
+type Signer struct {
+	key    string
+	secret string
+}
+
+// NewSigner creates a Signer using the API key and secret.
+func NewSigner(key string, secret string) *Signer {
+	return &Signer{key: key, secret: secret}
+}
+
+// Sign sets the Authorization, X-YC-Timestamp and content headers of the request signed at the given time.
+// The escaped URL path of the request is signed, so it must be the API path, e.g. /business/payments, and the
+// body is read and replaced so that it can still be sent.
+func (s *Signer) Sign(req *http.Request, t time.Time) error {
+	body, err := readBody(req)
+	if err != nil {
+		return err
+	}
+
+	for key, value := range signatureHeaders(s.key, s.secret, req.Method, req.URL.EscapedPath(), body, t) {
+		req.Header.Set(key, value)
+	}
+
+	return nil
+}
+
+// Verifier verifies the signature of requests made to the API, e.g. by a local emulator.
+type Verifier struct {
+	clock     Clock
+	secrets   map[string]string
+	tolerance time.Duration
+}
+
+// WithVerifierTolerance configures the maximum difference between the time a request was signed at and the
+// time it is verified at. A tolerance of zero disables the check.
+func WithVerifierTolerance(tolerance time.Duration) func(v *Verifier) {
+	return func(v *Verifier) {
+		if tolerance >= 0 {
+			v.tolerance = tolerance
+		}
+	}
+}
+
+// WithVerifierClock configures the clock used to check the timestamp of requests.
+func WithVerifierClock(clock Clock) func(v *Verifier) {
+	return func(v *Verifier) {
+		if clock != nil {
+			v.clock = clock
+		}
+	}
+}
+
+// NewVerifier creates a Verifier accepting requests signed with any of the secrets, keyed by API key.
+func NewVerifier(secrets map[string]string, opts ...func(v *Verifier)) *Verifier {
+	v := &Verifier{
+		clock:     ClockFunc(time.Now),
+		secrets:   make(map[string]string, len(secrets)),
+		tolerance: DefaultSignatureTolerance,
+	}
+
+	for key, secret := range secrets {
+		v.secrets[key] = secret
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// Verify checks that the request is signed by a known API key within the tolerance and returns the key.
+// The body is read and replaced so that it can still be handled.
+func (v *Verifier) Verify(req *http.Request) (string, error) {
+	scheme, credentials, _ := strings.Cut(req.Header.Get("Authorization"), " ")
+	key, signature, ok := strings.Cut(credentials, ":")
+	if scheme != AuthorizationScheme || !ok || key == "" || signature == "" {
+		return "", ErrMissingSignature
+	}
+
+	timestamp := req.Header.Get(TimestampHeader)
+	signedAt, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return "", ErrMissingSignature
+	}
+
+	secret, ok := v.secrets[key]
+	if !ok {
+		return "", ErrUnknownKey
+	}
+
+	body, err := readBody(req)
+	if err != nil {
+		return "", err
+	}
+
+	want := sign(secret, req.Method, req.URL.EscapedPath(), body, timestamp)
+	if !hmac.Equal([]byte(signature), []byte(want)) {
+		return "", ErrInvalidSignature
+	}
+
+	if age := v.clock.Now().Sub(signedAt); v.tolerance > 0 && age.Abs() > v.tolerance {
+		return "", fmt.Errorf("%w: signed %s ago", ErrInvalidTimestamp, age)
+	}
+
+	return key, nil
+}
+
+// signatureHeaders generates HTTP headers required for authentication using HMAC with SHA-256.
+func signatureHeaders(key, secret, method, path string, body []byte, t time.Time) map[string]string {
+	timestamp := t.UTC().Format(time.RFC3339)
+
+	headers := map[string]string{
+		"Accept":        "application/json",
+		"Authorization": fmt.Sprintf("%s %s:%s", AuthorizationScheme, key, sign(secret, method, path, body, timestamp)),
+		TimestampHeader: timestamp,
+	}
+
+	if len(body) != 0 {
+		headers["Content-Type"] = "application/json charset=utf-8"
+	}
+
+	return headers
+}
+
+// sign computes the base64 encoded HMAC-SHA256 of the timestamp, path, method and, if present, the base64
+// encoded SHA-256 hash of the body.
+func sign(secret, method, path string, body []byte, timestamp string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte(path))
+	mac.Write([]byte(method))
+
+	if len(body) != 0 {
+		bodyHash := sha256.Sum256(body)
+		mac.Write([]byte(base64.StdEncoding.EncodeToString(bodyHash[:])))
+	}
+
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// readBody reads the body of the request and replaces it with a reader over the same bytes.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("yellowcard: read request body - %w", err)
+	}
+
+	_ = req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
@@ -0,0 +1,135 @@
+package yellowcard
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSigner_Sign(t *testing.T) {
+	var (
+		fixedTime = time.Date(2024, time.June, 14, 16, 20, 0, 0, time.UTC)
+		signer    = NewSigner("key", "secret")
+	)
+
+	req, err := http.NewRequest(http.MethodPost, "https://api.yellowcard.io/test", strings.NewReader(`{"data":"value"}`))
+	assert.NoError(t, err)
+	assert.NoError(t, signer.Sign(req, fixedTime))
+
+	assert.Equal(t, "YcHmacV1 key:X/J/HnTGlVDhHEuu1XlEy3Fsy2tpRM+WHduSha2wvbw=", req.Header.Get("Authorization"))
+	assert.Equal(t, "2024-06-14T16:20:00Z", req.Header.Get(TimestampHeader))
+	assert.Equal(t, "application/json charset=utf-8", req.Header.Get("Content-Type"))
+
+	body, err := io.ReadAll(req.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"data":"value"}`, string(body))
+
+	req, err = http.NewRequest(http.MethodGet, "https://api.yellowcard.io/test", nil)
+	assert.NoError(t, err)
+	assert.NoError(t, signer.Sign(req, fixedTime))
+	assert.Equal(t, "YcHmacV1 key:QEwrY3n9vsnI53x07zW6+XVNWy+933g/zksnwHaKfsU=", req.Header.Get("Authorization"))
+	assert.Empty(t, req.Header.Get("Content-Type"))
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	var (
+		signedAt = time.Date(2024, time.June, 14, 16, 20, 0, 0, time.UTC)
+		verifier = NewVerifier(map[string]string{"key": "secret"},
+			WithVerifierClock(ClockFunc(func() time.Time { return signedAt.Add(time.Minute) })),
+		)
+		newRequest = func(t *testing.T, key, secret, body string, at time.Time) *http.Request {
+			t.Helper()
+
+			req, err := http.NewRequest(http.MethodPost, "http://localhost:8080/business/payments", strings.NewReader(body))
+			assert.NoError(t, err)
+			assert.NoError(t, NewSigner(key, secret).Sign(req, at))
+			return req
+		}
+	)
+
+	t.Run("valid signature", func(t *testing.T) {
+		req := newRequest(t, "key", "secret", `{"amount":100}`, signedAt)
+
+		key, err := verifier.Verify(req)
+		assert.NoError(t, err)
+		assert.Equal(t, "key", key)
+
+		body, err := io.ReadAll(req.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"amount":100}`, string(body))
+	})
+
+	t.Run("tampered body", func(t *testing.T) {
+		req := newRequest(t, "key", "secret", `{"amount":100}`, signedAt)
+		req.Body = io.NopCloser(bytes.NewBufferString(`{"amount":1000}`))
+
+		_, err := verifier.Verify(req)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		_, err := verifier.Verify(newRequest(t, "key", "other", `{"amount":100}`, signedAt))
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := verifier.Verify(newRequest(t, "other", "secret", `{"amount":100}`, signedAt))
+		assert.ErrorIs(t, err, ErrUnknownKey)
+	})
+
+	t.Run("stale timestamp", func(t *testing.T) {
+		_, err := verifier.Verify(newRequest(t, "key", "secret", `{"amount":100}`, signedAt.Add(-time.Hour)))
+		assert.ErrorIs(t, err, ErrInvalidTimestamp)
+
+		lenient := NewVerifier(map[string]string{"key": "secret"}, WithVerifierTolerance(0))
+		_, err = lenient.Verify(newRequest(t, "key", "secret", `{"amount":100}`, signedAt.Add(-time.Hour)))
+		assert.NoError(t, err)
+	})
+
+	t.Run("missing signature", func(t *testing.T) {
+		req := newRequest(t, "key", "secret", `{"amount":100}`, signedAt)
+		req.Header.Set("Authorization", "Bearer token")
+
+		_, err := verifier.Verify(req)
+		assert.ErrorIs(t, err, ErrMissingSignature)
+
+		req = newRequest(t, "key", "secret", `{"amount":100}`, signedAt)
+		req.Header.Del(TimestampHeader)
+
+		_, err = verifier.Verify(req)
+		assert.ErrorIs(t, err, ErrMissingSignature)
+	})
+}
+
+func TestVerifier_VerifyClientRequests(t *testing.T) {
+	var (
+		httpClient = newMockHttpClient()
+		client     = newTestClient(t, WithHttpClient(httpClient))
+		verifier   = NewVerifier(map[string]string{"key": "secret"})
+	)
+
+	httpClient.MockRequest(client.config.baseURL+"/business/payments/sequence-id/batch%2F42", func() (int, string) {
+		return http.StatusOK, `{"id":"0aa5bd35-b969-5d1d-ae7b-dfc0c4abbaf7"}`
+	})
+
+	httpClient.MockRequest(client.config.baseURL+"/business/payments", func() (int, string) {
+		return http.StatusOK, `{"id":"0aa5bd35-b969-5d1d-ae7b-dfc0c4abbaf7"}`
+	})
+
+	_, err := client.LookupPaymentBySequenceID(context.Background(), "batch/42")
+	assert.NoError(t, err)
+
+	_, err = client.MakePayment(context.Background(), &PaymentRequest{Amount: 100, SequenceID: "batch/43"}, false)
+	assert.NoError(t, err)
+
+	for _, req := range httpClient.requests {
+		key, err := verifier.Verify(req)
+		assert.NoError(t, err)
+		assert.Equal(t, "key", key)
+	}
+}
@@ -0,0 +1,115 @@
+package yellowcard
+
+import (
+	"cmp"
+	"context"
+	"time"
+)
+
+// OperationClass groups operations sharing the same default timeout.
+type OperationClass uint8
+
+const (
+	// OperationClassRead covers catalog reads, lookups and listings.
+	OperationClassRead OperationClass = iota + 1
+	// OperationClassAccountResolution covers account resolution, which waits on the bank or mobile money network.
+	OperationClassAccountResolution
+	// OperationClassMutation covers operations creating or changing payments, collections, settlements and webhooks.
+	OperationClassMutation
+)
+
+// Class returns the class of the operation.
+func (o Operation) Class() OperationClass {
+	switch o {
+	case OperationResolveBankAccount, OperationResolveMobileMoneyAccount:
+		return OperationClassAccountResolution
+	case OperationMakePayment,
+		OperationAcceptPaymentRequest,
+		OperationDenyPaymentRequest,
+		OperationSubmitCollectionRequest,
+		OperationAcceptCollectionRequest,
+		OperationDenyCollectionRequest,
+		OperationCreateSettlement,
+		OperationCreateWebhook,
+		OperationUpdateWebhook,
+		OperationRemoveWebhook:
+		return OperationClassMutation
+	default:
+		return OperationClassRead
+	}
+}
+
+// Timeouts are the default timeouts of each OperationClass. They bound all the attempts made by a request
+// whose context has no deadline. A zero timeout uses the timeout of DefaultTimeouts while a negative timeout
+// leaves requests of the class without a deadline.
+type Timeouts struct {
+	AccountResolution time.Duration
+	Mutation          time.Duration
+	Read              time.Duration
+}
+
+// DefaultTimeouts returns the Timeouts used unless configured otherwise using WithTimeouts.
+func DefaultTimeouts() Timeouts {
+	return Timeouts{
+		AccountResolution: 30 * time.Second,
+		Mutation:          60 * time.Second,
+		Read:              15 * time.Second,
+	}
+}
+
+// WithTimeouts configures the ClientConfig to use the timeouts for requests whose context has no deadline.
+// Classes whose timeout is zero keep their default timeout.
+func WithTimeouts(timeouts Timeouts) func(config *ClientConfig) {
+	return func(config *ClientConfig) {
+		defaults := DefaultTimeouts()
+
+		config.timeouts = Timeouts{
+			AccountResolution: cmp.Or(timeouts.AccountResolution, defaults.AccountResolution),
+			Mutation:          cmp.Or(timeouts.Mutation, defaults.Mutation),
+			Read:              cmp.Or(timeouts.Read, defaults.Read),
+		}
+	}
+}
+
+// WithOperationTimeout configures the ClientConfig to use the timeout for requests made by the operation whose
+// context has no deadline, overriding the timeout of its OperationClass. A zero timeout keeps the timeout of the
+// class while a negative timeout leaves requests of the operation without a deadline.
+func WithOperationTimeout(op Operation, timeout time.Duration) func(config *ClientConfig) {
+	return func(config *ClientConfig) {
+		if config.operationTimeouts == nil {
+			config.operationTimeouts = make(map[Operation]time.Duration)
+		}
+
+		config.operationTimeouts[op] = timeout
+	}
+}
+
+// timeout returns the timeout of the operation.
+func (c *ClientConfig) timeout(op Operation) time.Duration {
+	if timeout := c.operationTimeouts[op]; timeout != 0 {
+		return timeout
+	}
+
+	switch op.Class() {
+	case OperationClassAccountResolution:
+		return c.timeouts.AccountResolution
+	case OperationClassMutation:
+		return c.timeouts.Mutation
+	default:
+		return c.timeouts.Read
+	}
+}
+
+// withDefaultTimeout returns ctx bounded by the timeout of the operation unless it already has a deadline.
+func (c *ClientConfig) withDefaultTimeout(ctx context.Context, op Operation) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+
+	timeout := c.timeout(op)
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,119 @@
+package yellowcard
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestOperation_Class(t *testing.T) {
+	assert.Equal(t, OperationClassRead, OperationGetRates.Class())
+	assert.Equal(t, OperationClassRead, OperationLookupPayment.Class())
+	assert.Equal(t, OperationClassAccountResolution, OperationResolveMobileMoneyAccount.Class())
+	assert.Equal(t, OperationClassMutation, OperationMakePayment.Class())
+	assert.Equal(t, OperationClassMutation, OperationRemoveWebhook.Class())
+}
+
+func TestClient_DefaultTimeouts(t *testing.T) {
+	deadline := func(t *testing.T, req *http.Request) time.Duration {
+		t.Helper()
+
+		d, ok := req.Context().Deadline()
+		if !ok {
+			return 0
+		}
+
+		return time.Until(d)
+	}
+
+	t.Run("class timeout is applied without a deadline", func(t *testing.T) {
+		var (
+			httpClient = newMockHttpClient()
+			client     = newTestClient(t, WithHttpClient(httpClient))
+		)
+
+		httpClient.MockRequest(client.config.baseURL+"/business/account", func() (int, string) {
+			return http.StatusOK, `{"id":"deb55c03-9961-417a-9550-f5ba7fe258e9"}`
+		})
+
+		_, err := client.GetAccount(context.Background())
+		assert.NoError(t, err)
+		assert.InDelta(t, DefaultTimeouts().Read, deadline(t, httpClient.requests[0]), float64(time.Second))
+	})
+
+	t.Run("caller deadline is kept", func(t *testing.T) {
+		var (
+			httpClient = newMockHttpClient()
+			client     = newTestClient(t, WithHttpClient(httpClient))
+		)
+
+		httpClient.MockRequest(client.config.baseURL+"/business/account", func() (int, string) {
+			return http.StatusOK, `{"id":"deb55c03-9961-417a-9550-f5ba7fe258e9"}`
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		_, err := client.GetAccount(ctx)
+		assert.NoError(t, err)
+		assert.InDelta(t, 2*time.Minute, deadline(t, httpClient.requests[0]), float64(time.Second))
+	})
+
+	t.Run("operation timeout overrides class timeout", func(t *testing.T) {
+		var (
+			httpClient = newMockHttpClient()
+			client     = newTestClient(t,
+				WithHttpClient(httpClient),
+				WithTimeouts(Timeouts{Read: time.Second}),
+				WithOperationTimeout(OperationMakePayment, 90*time.Second),
+			)
+		)
+
+		httpClient.MockRequest(client.config.baseURL+"/business/payments", func() (int, string) {
+			return http.StatusOK, `{"id":"0aa5bd35-b969-5d1d-ae7b-dfc0c4abbaf7"}`
+		})
+
+		httpClient.MockRequest(client.config.baseURL+"/business/details/bank", func() (int, string) {
+			return http.StatusOK, `{"accountName":"Ken Adams"}`
+		})
+
+		_, err := client.MakePayment(context.Background(), &PaymentRequest{}, false)
+		assert.NoError(t, err)
+		assert.InDelta(t, 90*time.Second, deadline(t, httpClient.requests[0]), float64(time.Second))
+
+		_, err = client.ResolveBankAccount(context.Background(), &ResolveBankAccountRequest{})
+		assert.NoError(t, err)
+		assert.InDelta(t, DefaultTimeouts().AccountResolution, deadline(t, httpClient.requests[1]), float64(time.Second))
+	})
+
+	t.Run("negative timeout disables the deadline", func(t *testing.T) {
+		var (
+			httpClient = newMockHttpClient()
+			client     = newTestClient(t,
+				WithHttpClient(httpClient),
+				WithTimeouts(Timeouts{Read: -1}),
+				WithOperationTimeout(OperationResolveBankAccount, -1),
+			)
+		)
+
+		httpClient.MockRequest(client.config.baseURL+"/business/account", func() (int, string) {
+			return http.StatusOK, `{"id":"deb55c03-9961-417a-9550-f5ba7fe258e9"}`
+		})
+
+		httpClient.MockRequest(client.config.baseURL+"/business/details/bank", func() (int, string) {
+			return http.StatusOK, `{"accountName":"Ken Adams"}`
+		})
+
+		_, err := client.GetAccount(context.Background())
+		assert.NoError(t, err)
+		assert.Zero(t, deadline(t, httpClient.requests[0]))
+
+		_, err = client.ResolveBankAccount(context.Background(), &ResolveBankAccountRequest{})
+		assert.NoError(t, err)
+		assert.Zero(t, deadline(t, httpClient.requests[1]))
+
+		assert.Equal(t, DefaultTimeouts().Mutation, client.config.timeout(OperationMakePayment))
+	})
+}
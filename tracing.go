@@ -0,0 +1,100 @@
+package yellowcard
+
+import (
+	"context"
+)
+
+// Tracer starts a Span for every Client operation, e.g. to integrate the Client with OpenTelemetry.
+type Tracer interface {
+	// Start starts a span for the operation. The returned context is used for all requests made by the operation.
+	Start(ctx context.Context, op Operation) (context.Context, Span)
+}
+
+// Span is a single traced Client operation.
+type Span interface {
+	// End ends the span with the attributes collected during the operation and the error it returned, if any.
+	End(attrs SpanAttributes, err error)
+}
+
+// SpanAttributes describe a traced operation.
+type SpanAttributes struct {
+	CollectionID string
+	Operation    Operation
+	PaymentID    string
+	// Retries is the number of requests made by the operation after the first one.
+	Retries    int
+	SequenceID string
+	// StatusCode is the status code of the last response received, or 0 when none was received.
+	StatusCode int
+}
+
+// WithTracer configures the ClientConfig to trace every operation using the Tracer.
+func WithTracer(tracer Tracer) func(config *ClientConfig) {
+	return func(config *ClientConfig) {
+		config.tracer = tracer
+	}
+}
+
+type spanKey struct{}
+
+// span collects the attributes of an operation traced by Client.startSpan.
+type span struct {
+	attrs    SpanAttributes
+	attempts int
+	span     Span
+}
+
+// startSpan starts a span for the operation. The returned span is nil when no Tracer is configured.
+func (cl *Client) startSpan(ctx context.Context, op Operation, attrs SpanAttributes) (context.Context, *span) {
+	if cl.config.tracer == nil {
+		return ctx, nil
+	}
+
+	attrs.Operation = op
+
+	ctx, s := cl.config.tracer.Start(ctx, op)
+	sp := &span{attrs: attrs, span: s}
+	return context.WithValue(ctx, spanKey{}, sp), sp
+}
+
+// spanFromContext returns the span of the operation that ctx belongs to, if any.
+func spanFromContext(ctx context.Context) *span {
+	sp, _ := ctx.Value(spanKey{}).(*span)
+	return sp
+}
+
+// traceAttempt records the status code of the attempt on the span of its operation.
+func traceAttempt(ctx context.Context, a *attempt) {
+	sp := spanFromContext(ctx)
+	if sp == nil {
+		return
+	}
+
+	sp.attempts++
+	sp.attrs.StatusCode = 0
+
+	if a.response != nil {
+		sp.attrs.StatusCode = a.response.StatusCode
+	}
+}
+
+// end ends the span, taking the IDs of the payment or collection from the operation's result.
+func (sp *span) end(result any, err error) {
+	if sp == nil {
+		return
+	}
+
+	switch r := result.(type) {
+	case *Payment:
+		if r != nil {
+			sp.attrs.PaymentID, sp.attrs.SequenceID = r.ID, r.SequenceID
+		}
+	case *Collection:
+		if r != nil {
+			sp.attrs.CollectionID, sp.attrs.SequenceID = r.ID, r.SequenceID
+		}
+	}
+
+	sp.attrs.Retries = max(sp.attempts-1, 0)
+	sp.span.End(sp.attrs, err)
+}
@@ -0,0 +1,68 @@
+package yellowcard
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+type recordedSpan struct {
+	op    Operation
+	attrs SpanAttributes
+	err   error
+	ended bool
+}
+
+func (s *recordedSpan) End(attrs SpanAttributes, err error) {
+	s.attrs, s.err, s.ended = attrs, err, true
+}
+
+type recordingTracer struct {
+	spans []*recordedSpan
+}
+
+func (t *recordingTracer) Start(ctx context.Context, op Operation) (context.Context, Span) {
+	s := &recordedSpan{op: op}
+	t.spans = append(t.spans, s)
+	return ctx, s
+}
+
+func TestClient_Tracer(t *testing.T) {
+	var (
+		httpClient = newMockHttpClient()
+		tracer     = &recordingTracer{}
+		client     = newTestClient(t, WithHttpClient(httpClient), WithTracer(tracer))
+	)
+
+	httpClient.MockRequest(client.config.baseURL+"/business/collections/sequence-id/nsahHJODjx", func() (int, string) {
+		return http.StatusOK, `{"id":"1f3b3d8c-6a3e-4d25-9c3f-1b9f1d1e2a7b","sequenceId":"nsahHJODjx"}`
+	})
+
+	_, err := client.LookupCollectionBySequenceID(context.Background(), "nsahHJODjx")
+	assert.NoError(t, err)
+
+	_, err = client.MakePayment(context.Background(), &PaymentRequest{
+		CustomerType: CustomerTypeInstitution,
+		SequenceID:   "kq3RfDx81L",
+	}, false)
+	assert.ErrorIs(t, err, ErrBusinessSenderRequired)
+
+	assert.Len(t, tracer.spans, 2)
+
+	collection := tracer.spans[0]
+	assert.True(t, collection.ended)
+	assert.NoError(t, collection.err)
+	assert.Equal(t, OperationLookupCollectionBySequenceID, collection.op)
+	assert.Equal(t, SpanAttributes{
+		CollectionID: "1f3b3d8c-6a3e-4d25-9c3f-1b9f1d1e2a7b",
+		Operation:    OperationLookupCollectionBySequenceID,
+		SequenceID:   "nsahHJODjx",
+		StatusCode:   http.StatusOK,
+	}, collection.attrs)
+
+	payment := tracer.spans[1]
+	assert.True(t, payment.ended)
+	assert.ErrorIs(t, payment.err, ErrBusinessSenderRequired)
+	assert.Equal(t, SpanAttributes{Operation: OperationMakePayment, SequenceID: "kq3RfDx81L"}, payment.attrs)
+}
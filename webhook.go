@@ -0,0 +1,155 @@
+package yellowcard
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	// WebhookSignatureHeader is the header carrying the signature of a webhook payload.
+	WebhookSignatureHeader = "X-YC-Signature"
+
+	// DefaultWebhookTolerance is the maximum age of a webhook event accepted by a WebhookHandler.
+	DefaultWebhookTolerance = 5 * time.Minute
+
+	_maxWebhookBodySize = 1 << 20
+)
+
+// ErrInvalidWebhookSignature is returned when a webhook payload does not match its signature.
+var ErrInvalidWebhookSignature = errors.New("yellowcard: invalid webhook signature")
+
+// ErrStaleWebhookEvent is returned when a webhook event was emitted outside the accepted tolerance.
+var ErrStaleWebhookEvent = errors.New("yellowcard: stale webhook event")
+
+// WebhookHandlerFunc is called with every verified webhook event. Returning an error responds with
+// http.StatusInternalServerError so that the event is redelivered.
+type WebhookHandlerFunc func(ctx context.Context, event *WebhookEvent) error
+
+// WebhookHandler is a http.Handler that verifies and decodes webhook events before passing them on.
+type WebhookHandler struct {
+	client    *Client
+	fn        WebhookHandlerFunc
+	now       func() time.Time
+	tolerance time.Duration
+}
+
+// WithWebhookTolerance configures the maximum age of an event accepted by the WebhookHandler.
+// A tolerance of zero disables the check.
+func WithWebhookTolerance(tolerance time.Duration) func(h *WebhookHandler) {
+	return func(h *WebhookHandler) {
+		if tolerance >= 0 {
+			h.tolerance = tolerance
+		}
+	}
+}
+
+// WebhookHandler returns a http.Handler that verifies webhook events using the client's secret and
+// passes them on to fn.
+func (cl *Client) WebhookHandler(fn WebhookHandlerFunc, opts ...func(h *WebhookHandler)) *WebhookHandler {
+	h := &WebhookHandler{
+		client:    cl,
+		fn:        fn,
+		now:       cl.now,
+		tolerance: DefaultWebhookTolerance,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// Parse verifies the signature and age of a webhook payload and decodes it into a WebhookEvent.
+// The signature is verified using the secret provided by the client's CredentialsProvider.
+func (h *WebhookHandler) Parse(ctx context.Context, payload []byte, signature string) (*WebhookEvent, error) {
+	creds, err := h.client.credentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !validSignature(creds.Secret, payload, signature) {
+		return nil, ErrInvalidWebhookSignature
+	}
+
+	var event *WebhookEvent
+	if err = json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("yellowcard: deserialize webhook event - %v", err)
+	}
+
+	if h.tolerance > 0 {
+		age := h.now().Sub(event.Time())
+		if event.ExecutedAt == 0 || age > h.tolerance || age < -h.tolerance {
+			return nil, ErrStaleWebhookEvent
+		}
+	}
+
+	switch {
+	case event.Event.IsPayment():
+		err = json.Unmarshal(payload, &event.Payment)
+	case event.Event.IsCollection():
+		err = json.Unmarshal(payload, &event.Collection)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("yellowcard: deserialize webhook event - %v", err)
+	}
+
+	return event, nil
+}
+
+// ServeHTTP handles webhook deliveries.
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, _maxWebhookBodySize))
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	event, err := h.Parse(r.Context(), payload, r.Header.Get(WebhookSignatureHeader))
+	switch {
+	case errors.Is(err, ErrInvalidWebhookSignature), errors.Is(err, ErrStaleWebhookEvent):
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	case errors.Is(err, ErrCredentialsUnavailable):
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	case err != nil:
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	if err = h.fn(r.Context(), event); err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// validSignature reports whether signature is the base64 encoded HMAC-SHA256 of the payload.
+func validSignature(secret string, payload []byte, signature string) bool {
+	got, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+
+	return hmac.Equal(got, mac.Sum(nil))
+}
@@ -0,0 +1,145 @@
+package yellowcard
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func signWebhookPayload(secret string, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestWebhookHandler_ServeHTTP(t *testing.T) {
+	var (
+		client   = newTestClient(t)
+		now      = time.Date(2024, time.June, 15, 7, 10, 0, 0, time.UTC)
+		received *WebhookEvent
+		payload  = fmt.Sprintf(`
+		{
+		   "id":"d83011e8-341f-5e3e-b908-84cb4a552fcc",
+		   "sequenceId":"AovQYlKGkz",
+		   "status":"complete",
+		   "apiKey":"key",
+		   "event":"PAYMENT.COMPLETE",
+		   "executedAt":%d
+		}`, now.Add(-time.Minute).UnixMilli())
+	)
+
+	handler := client.WebhookHandler(func(ctx context.Context, event *WebhookEvent) error {
+		received = event
+		return nil
+	})
+	handler.now = func() time.Time { return now }
+
+	tests := []struct {
+		name      string
+		method    string
+		payload   string
+		signature string
+		want      int
+	}{
+		{
+			name:      "Tests valid event",
+			method:    http.MethodPost,
+			payload:   payload,
+			signature: signWebhookPayload("secret", payload),
+			want:      http.StatusOK,
+		},
+		{
+			name:      "Tests invalid signature",
+			method:    http.MethodPost,
+			payload:   payload,
+			signature: signWebhookPayload("other", payload),
+			want:      http.StatusUnauthorized,
+		},
+		{
+			name:      "Tests missing signature",
+			method:    http.MethodPost,
+			payload:   payload,
+			signature: "",
+			want:      http.StatusUnauthorized,
+		},
+		{
+			name:      "Tests stale event",
+			method:    http.MethodPost,
+			payload:   `{"id":"1","event":"PAYMENT.COMPLETE","executedAt":1718000000000}`,
+			signature: signWebhookPayload("secret", `{"id":"1","event":"PAYMENT.COMPLETE","executedAt":1718000000000}`),
+			want:      http.StatusUnauthorized,
+		},
+		{
+			name:      "Tests malformed payload",
+			method:    http.MethodPost,
+			payload:   `{"id":`,
+			signature: signWebhookPayload("secret", `{"id":`),
+			want:      http.StatusBadRequest,
+		},
+		{
+			name:   "Tests unsupported method",
+			method: http.MethodGet,
+			want:   http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received = nil
+
+			req := httptest.NewRequest(tt.method, "/webhooks", strings.NewReader(tt.payload))
+			req.Header.Set(WebhookSignatureHeader, tt.signature)
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.want, rec.Code)
+			assert.Equal(t, tt.want == http.StatusOK, received != nil)
+		})
+	}
+
+	assert.Nil(t, received)
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(payload))
+	req.Header.Set(WebhookSignatureHeader, signWebhookPayload("secret", payload))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotNil(t, received)
+	assert.Equal(t, EventPaymentComplete, received.Event)
+	assert.NotNil(t, received.Payment)
+	assert.Nil(t, received.Collection)
+	assert.Equal(t, "d83011e8-341f-5e3e-b908-84cb4a552fcc", received.Payment.ID)
+	assert.Equal(t, "complete", received.Payment.Status)
+}
+
+func TestWebhookHandler_CallbackError(t *testing.T) {
+	var (
+		client  = newTestClient(t)
+		payload = `{"id":"4f8a0b4e","sequenceId":"kKJmnTWuYz","status":"complete","event":"COLLECTION.COMPLETE"}`
+		handler = client.WebhookHandler(func(ctx context.Context, event *WebhookEvent) error {
+			assert.NotNil(t, event.Collection)
+			assert.Equal(t, "kKJmnTWuYz", event.Collection.SequenceID)
+			return errors.New("database unavailable")
+		}, WithWebhookTolerance(0))
+	)
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(payload))
+	req.Header.Set(WebhookSignatureHeader, signWebhookPayload("secret", payload))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
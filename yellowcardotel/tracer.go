@@ -0,0 +1,105 @@
+// Package yellowcardotel traces yellowcard.Client operations using OpenTelemetry.
+//
+//	client, err := yellowcard.New("API_KEY", "SECRET_KEY", yellowcard.WithTracer(yellowcardotel.NewTracer()))
+package yellowcardotel
+
+import (
+	"context"
+	yellowcard "github.com/jwambugu/yellowcard-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope name of the tracer.
+const ScopeName = "github.com/jwambugu/yellowcard-go/yellowcardotel"
+
+// Attribute keys set on every span.
+const (
+	AttributeCollectionID = attribute.Key("yellowcard.collection_id")
+	AttributeOperation    = attribute.Key("yellowcard.operation")
+	AttributePaymentID    = attribute.Key("yellowcard.payment_id")
+	AttributeRetries      = attribute.Key("yellowcard.retries")
+	AttributeSequenceID   = attribute.Key("yellowcard.sequence_id")
+	AttributeStatusCode   = attribute.Key("http.response.status_code")
+)
+
+// Tracer is a yellowcard.Tracer creating OpenTelemetry spans.
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+// Option configures a Tracer.
+type Option func(t *config)
+
+type config struct {
+	provider trace.TracerProvider
+}
+
+// WithTracerProvider sets the provider used to create spans. The global provider is used by default.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.provider = provider
+	}
+}
+
+// NewTracer creates a Tracer.
+func NewTracer(opts ...Option) *Tracer {
+	c := &config{}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.provider == nil {
+		c.provider = otel.GetTracerProvider()
+	}
+
+	return &Tracer{tracer: c.provider.Tracer(ScopeName)}
+}
+
+// Start starts a client span named after the operation, e.g. "yellowcard.MakePayment".
+func (t *Tracer) Start(ctx context.Context, op yellowcard.Operation) (context.Context, yellowcard.Span) {
+	ctx, s := t.tracer.Start(ctx, "yellowcard."+op.String(),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(AttributeOperation.String(op.String())),
+	)
+
+	return ctx, &span{span: s}
+}
+
+type span struct {
+	span trace.Span
+}
+
+// End sets the attributes on the span and records err, if any, before ending it.
+func (s *span) End(attrs yellowcard.SpanAttributes, err error) {
+	kv := []attribute.KeyValue{
+		AttributeRetries.Int(attrs.Retries),
+	}
+
+	if attrs.CollectionID != "" {
+		kv = append(kv, AttributeCollectionID.String(attrs.CollectionID))
+	}
+
+	if attrs.PaymentID != "" {
+		kv = append(kv, AttributePaymentID.String(attrs.PaymentID))
+	}
+
+	if attrs.SequenceID != "" {
+		kv = append(kv, AttributeSequenceID.String(attrs.SequenceID))
+	}
+
+	if attrs.StatusCode != 0 {
+		kv = append(kv, AttributeStatusCode.Int(attrs.StatusCode))
+	}
+
+	s.span.SetAttributes(kv...)
+
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+
+	s.span.End()
+}
@@ -0,0 +1,162 @@
+package yellowcardotel
+
+import (
+	"bytes"
+	"context"
+	yellowcard "github.com/jwambugu/yellowcard-go"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// httpClientFunc responds to requests using a function.
+type httpClientFunc func(req *http.Request) (*http.Response, error)
+
+func (fn httpClientFunc) Do(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
+
+func respond(status int, body string) *http.Response {
+	return &http.Response{
+		Status:     http.StatusText(status),
+		StatusCode: status,
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+	}
+}
+
+func newTracer() (*Tracer, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	return NewTracer(WithTracerProvider(provider)), exporter
+}
+
+func newClient(t *testing.T, opts ...func(*yellowcard.ClientConfig)) *yellowcard.Client {
+	t.Helper()
+
+	client, err := yellowcard.New("key", "secret", opts...)
+	if err != nil {
+		t.Fatalf("yellowcardotel: new client - %v", err)
+	}
+
+	return client
+}
+
+func attributes(s tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range s.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+
+	return attrs
+}
+
+func TestTracer(t *testing.T) {
+	tracer, exporter := newTracer()
+
+	var (
+		statuses   = []int{http.StatusServiceUnavailable, http.StatusOK}
+		httpClient = httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			assert.True(t, trace.SpanContextFromContext(req.Context()).IsValid())
+
+			status := statuses[0]
+			statuses = statuses[1:]
+
+			return respond(status, `{"id":"0aa5bd35-b969-5d1d-ae7b-dfc0c4abbaf7","sequenceId":"nsahHJODjx"}`), nil
+		})
+		client = newClient(t,
+			yellowcard.WithHttpClient(httpClient),
+			yellowcard.WithTracer(tracer),
+			yellowcard.WithRetryPolicy(&yellowcard.RetryPolicy{
+				MaxAttempts: 2,
+				MinBackoff:  time.Millisecond,
+				MaxBackoff:  time.Millisecond,
+			}),
+		)
+	)
+
+	_, err := client.LookupPaymentBySequenceID(context.Background(), "nsahHJODjx")
+	assert.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 1)
+
+	s := spans[0]
+	assert.Equal(t, "yellowcard.LookupPaymentBySequenceID", s.Name)
+	assert.Equal(t, trace.SpanKindClient, s.SpanKind)
+	assert.Equal(t, codes.Unset, s.Status.Code)
+
+	attrs := attributes(s)
+	assert.Equal(t, "LookupPaymentBySequenceID", attrs[AttributeOperation].AsString())
+	assert.Equal(t, "0aa5bd35-b969-5d1d-ae7b-dfc0c4abbaf7", attrs[AttributePaymentID].AsString())
+	assert.Equal(t, "nsahHJODjx", attrs[AttributeSequenceID].AsString())
+	assert.EqualValues(t, http.StatusOK, attrs[AttributeStatusCode].AsInt64())
+	assert.EqualValues(t, 1, attrs[AttributeRetries].AsInt64())
+}
+
+func TestTracer_Error(t *testing.T) {
+	tracer, exporter := newTracer()
+
+	var (
+		httpClient = httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			return respond(http.StatusNotFound, `{"code":"NotFound","message":"payment not found"}`), nil
+		})
+		client = newClient(t, yellowcard.WithHttpClient(httpClient), yellowcard.WithTracer(tracer))
+	)
+
+	_, err := client.LookupPayment(context.Background(), "0aa5bd35-b969-5d1d-ae7b-dfc0c4abbaf7")
+	assert.Error(t, err)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 1)
+
+	s := spans[0]
+	assert.Equal(t, codes.Error, s.Status.Code)
+	assert.Len(t, s.Events, 1)
+
+	attrs := attributes(s)
+	assert.Equal(t, "0aa5bd35-b969-5d1d-ae7b-dfc0c4abbaf7", attrs[AttributePaymentID].AsString())
+	assert.EqualValues(t, http.StatusNotFound, attrs[AttributeStatusCode].AsInt64())
+	assert.EqualValues(t, 0, attrs[AttributeRetries].AsInt64())
+}
+
+func TestTracer_NestedOperations(t *testing.T) {
+	tracer, exporter := newTracer()
+
+	var (
+		httpClient = httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/business/channels" {
+				return respond(http.StatusOK, `{"channels":[{"id":"c1","status":"active","rampType":"withdraw",
+					"currency":"KES","min":1,"max":1000000}]}`), nil
+			}
+
+			return respond(http.StatusOK, `{"rates":[{"code":"KES","buy":130,"sell":128,"rateId":"r1"}]}`), nil
+		})
+		client = newClient(t, yellowcard.WithHttpClient(httpClient), yellowcard.WithTracer(tracer))
+	)
+
+	_, err := client.Quote(context.Background(), &yellowcard.QuoteRequest{
+		Amount:    10,
+		ChannelID: "c1",
+		Country:   "KE",
+	})
+	assert.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 3)
+
+	names := make(map[string]tracetest.SpanStub)
+	for _, s := range spans {
+		names[s.Name] = s
+	}
+
+	quote := names["yellowcard.Quote"]
+	assert.Equal(t, quote.SpanContext.SpanID(), names["yellowcard.ListChannels"].Parent.SpanID())
+	assert.Equal(t, quote.SpanContext.SpanID(), names["yellowcard.GetRates"].Parent.SpanID())
+}